@@ -0,0 +1,51 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gf256
+
+import "fmt"
+
+// AESSBox returns the AES substitution box: each byte is mapped to its
+// multiplicative inverse in f (with zero mapped to zero) followed by the
+// AES affine transformation. The result is the S-box of FIPS-197 when f is
+// the Rijndael field returned by NewAESField. AESSBox panics unless f has
+// 256 elements, since the S-box maps bytes to bytes.
+func (f *Field) AESSBox() [256]byte {
+	if f.m != 8 {
+		panic(fmt.Sprintf("AESSBox: GF(2^%d) elements are not bytes.", f.m))
+	}
+	var sbox [256]byte
+	for x := range sbox {
+		inv, _ := f.Inv(Num(x)) // Inv returns zero for zero.
+		sbox[x] = aesAffine(byte(inv))
+	}
+	return sbox
+}
+
+// AESInvSBox returns the inverse of the substitution box returned by AESSBox.
+func (f *Field) AESInvSBox() [256]byte {
+	var invSBox [256]byte
+	for x, y := range f.AESSBox() {
+		invSBox[y] = byte(x)
+	}
+	return invSBox
+}
+
+// aesAffine applies the affine transformation of FIPS-197 section 5.1.1:
+// bit i of the result is b_i+b_(i+4)+b_(i+5)+b_(i+6)+b_(i+7)+c_i with
+// indices modulo 8 and c == 0x63.
+func aesAffine(b byte) byte {
+	rotl := func(b byte, n uint) byte { return b<<n | b>>(8-n) }
+	return b ^ rotl(b, 1) ^ rotl(b, 2) ^ rotl(b, 3) ^ rotl(b, 4) ^ 0x63
+}
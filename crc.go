@@ -0,0 +1,38 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gf256
+
+import (
+	"fmt"
+)
+
+// CRCRemainder returns the remainder of data(x)×x⁸ modulo poly, where data(x)
+// is the polynomial over GF(2) whose coefficients are the bits of data, with
+// the most significant bit of data[0] as the highest-order coefficient. This
+// is the 8-bit CRC of data with generator poly, zero initial value, and no
+// final XOR or bit reflection. The polynomial poly must have degree 8 but
+// need not be irreducible; CRCRemainder panics otherwise.
+func CRCRemainder(data []byte, poly Irreducible) Num {
+	if poly>>8 != 1 {
+		panic(fmt.Sprintf("CRCRemainder: %v does not have degree 8.", poly))
+	}
+	// The code below implements Horner's rule one byte at a time: adding the
+	// next byte and multiplying by x⁸ modulo poly.
+	crc := Num(0)
+	for _, b := range data {
+		crc = multiply(crc^Num(b), 0x100, poly)
+	}
+	return crc
+}
@@ -0,0 +1,85 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gf256
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleCRCRemainder() {
+	// CRC-8/SMBUS uses the generator x⁸+x²+x+1.
+	fmt.Printf("%#02x\n", CRCRemainder([]byte("123456789"), 0x107))
+	// Output: 0xf4
+}
+
+// referenceCRC8 is the conventional bitwise CRC-8 with zero initial value.
+func referenceCRC8(data []byte, poly byte) byte {
+	crc := byte(0)
+	for _, b := range data {
+		crc ^= b
+		for i := 0; i < 8; i++ {
+			if crc&0x80 != 0 {
+				crc = crc<<1 ^ poly
+			} else {
+				crc = crc << 1
+			}
+		}
+	}
+	return crc
+}
+
+func TestCRCRemainder(t *testing.T) {
+	testData := []struct {
+		data     string
+		poly     Irreducible
+		expected Num
+	}{
+		// Check values from the CRC catalogue for "123456789".
+		{"123456789", 0x107, 0xf4}, // CRC-8/SMBUS
+		{"123456789", 0x11d, 0x37}, // CRC-8/GSM-A
+		{"123456789", 0x19b, 0xea}, // CRC-8/LTE
+		{"", 0x107, 0x00},
+	}
+	for _, data := range testData {
+		if actual := CRCRemainder([]byte(data.data), data.poly); actual != data.expected {
+			t.Errorf("CRCRemainder(%q, %v): expected %#02x, actual %#02x.", data.data, data.poly, uint(data.expected), uint(actual))
+		}
+	}
+	inputs := [][]byte{
+		{0x00},
+		{0x80},
+		{0xff, 0xff, 0xff, 0xff},
+		[]byte("The quick brown fox jumps over the lazy dog"),
+	}
+	for _, poly := range []Irreducible{0x107, 0x11d, 0x131, 0x19b, 0x1d5} {
+		for _, input := range inputs {
+			expected := Num(referenceCRC8(input, byte(poly)))
+			if actual := CRCRemainder(input, poly); actual != expected {
+				t.Errorf("CRCRemainder(%v, %v): expected %#02x, actual %#02x.", input, poly, uint(expected), uint(actual))
+			}
+		}
+	}
+	for _, poly := range []Irreducible{0x07, 0x211} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Expected CRCRemainder(..., %v) to panic.", poly)
+				}
+			}()
+			CRCRemainder([]byte{0x01}, poly)
+		}()
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gf256
+
+import (
+	"fmt"
+	"sync"
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding consists
+// of the irreducible polynomial as two big-endian bytes, the generator as
+// one byte, and the 255 entries of the exp table. UnmarshalBinary still
+// recomputes the table and only uses the stored copy as a consistency
+// check. Only GF[2⁸] fields can be marshaled.
+func (f *Field) MarshalBinary() ([]byte, error) {
+	if f.m != 8 {
+		return nil, fmt.Errorf("Cannot marshal GF(2^%d); only GF[2⁸] is supported.", f.m)
+	}
+	data := make([]byte, 3, 3+len(f.expTable))
+	data[0] = byte(f.poly >> 8)
+	data[1] = byte(f.poly)
+	data[2] = byte(f.g)
+	for _, n := range f.expTable {
+		data = append(data, byte(n))
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It accepts the
+// encoding produced by MarshalBinary as well as the three-byte prefix
+// holding only the polynomial and generator, in which case the tables are
+// rebuilt as in NewField. The parameters are validated as in NewField and
+// a supplied exp table must list the successive powers 1, g, g², … of the
+// generator g, each computed modulo the polynomial. Since the 255 powers
+// must then be distinct, this also confirms that g is primitive.
+// UnmarshalBinary modifies its receiver and must not be called
+// concurrently with any other use of f.
+func (f *Field) UnmarshalBinary(data []byte) error {
+	if len(data) != 3 && len(data) != 3+255 {
+		return fmt.Errorf("Cannot unmarshal field from %d bytes.", len(data))
+	}
+	polynomial := Irreducible(data[0])<<8 | Irreducible(data[1])
+	generator := Num(data[2])
+	if len(data) == 3 {
+		g, err := NewField(polynomial, generator)
+		if err != nil {
+			return err
+		}
+		f.set(g)
+		return nil
+	}
+	if err := checkParameters(8, polynomial, generator); err != nil {
+		return err
+	}
+	g := newField(8, polynomial, generator)
+	seen := make([]bool, 256)
+	power := g.One()
+	for i, b := range data[3:] {
+		n := Num(b)
+		if n != power {
+			return fmt.Errorf("Cannot unmarshal field: exp table entry %d does not match generator.", i)
+		}
+		if seen[n] {
+			return fmt.Errorf("Cannot unmarshal field: %v is not a generator.", generator)
+		}
+		seen[n] = true
+		g.expTable[i] = n
+		g.logTable[n] = i
+		power = multiply(power, generator, polynomial)
+	}
+	g.buildSquareTable()
+	g.buildZechTable()
+	f.set(g)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler using the binary string
+// representation returned by String.
+func (n Num) MarshalText() ([]byte, error) {
+	return []byte(n.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts the binary
+// string representations accepted by ParseNum.
+func (n *Num) UnmarshalText(text []byte) error {
+	parsed, err := ParseNum(string(text))
+	if err != nil {
+		return err
+	}
+	*n = parsed
+	return nil
+}
+
+// set replaces the parameters and tables of f with those of g and discards
+// any multiplication table enabled on f.
+func (f *Field) set(g *Field) {
+	f.m, f.poly, f.g = g.m, g.poly, g.g
+	f.expTable, f.logTable = g.expTable, g.logTable
+	f.squareTable, f.zechTable = g.squareTable, g.zechTable
+	f.mulTable.Store(nil)
+	f.mulOnce = sync.Once{}
+}
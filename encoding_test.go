@@ -0,0 +1,179 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gf256
+
+import "encoding/json"
+import "testing"
+
+// compareFields reports an error for every table entry where f and g differ.
+func compareFields(t *testing.T, f, g *Field) {
+	if f.Polynomial() != g.Polynomial() || f.Generator() != g.Generator() {
+		t.Errorf("Parameters differ: %v, %v and %v, %v.",
+			f.Polynomial(), f.Generator(), g.Polynomial(), g.Generator())
+	}
+	for i := 0; i < 255; i++ {
+		if f.Exp(i) != g.Exp(i) {
+			t.Errorf("Exp(%d) differs: %v and %v.", i, f.Exp(i), g.Exp(i))
+		}
+		zechF, okF := f.ZechLog(i)
+		zechG, okG := g.ZechLog(i)
+		if zechF != zechG || okF != okG {
+			t.Errorf("ZechLog(%d) differs: %v and %v.", i, zechF, zechG)
+		}
+	}
+	for i := uint(0); i < 256; i++ {
+		x := Num(i)
+		logF, errF := f.Log(x)
+		logG, errG := g.Log(x)
+		if logF != logG || (errF == nil) != (errG == nil) {
+			t.Errorf("Log(%v) differs: %v and %v.", x, logF, logG)
+		}
+		if f.Square(x) != g.Square(x) {
+			t.Errorf("Square(%v) differs: %v and %v.", x, f.Square(x), g.Square(x))
+		}
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	for _, parameters := range []struct {
+		polynomial Irreducible
+		generator  Num
+	}{
+		{0x11d, 0x02},
+		{0x11d, 0x04},
+		{0x11b, 0x03},
+	} {
+		f, err := NewField(parameters.polynomial, parameters.generator)
+		if err != nil {
+			t.Errorf("Could not create GF[2⁸]: %v.", err)
+			continue
+		}
+		data, err := f.MarshalBinary()
+		if err != nil {
+			t.Errorf("MarshalBinary: got error %v", err)
+			continue
+		}
+		if len(data) != 258 {
+			t.Errorf("MarshalBinary: unexpected length %d.", len(data))
+		}
+		g := &Field{}
+		if err := g.UnmarshalBinary(data); err != nil {
+			t.Errorf("UnmarshalBinary: got error %v", err)
+			continue
+		}
+		compareFields(t, f, g)
+		h := &Field{}
+		if err := h.UnmarshalBinary(data[:3]); err != nil {
+			t.Errorf("UnmarshalBinary of parameters only: got error %v", err)
+			continue
+		}
+		compareFields(t, f, h)
+	}
+}
+
+func TestUnmarshalBinaryErrors(t *testing.T) {
+	f, _ := NewField(0x11d, 0x02)
+	data, _ := f.MarshalBinary()
+	g := &Field{}
+	if err := g.UnmarshalBinary(data[:10]); err == nil {
+		t.Errorf("Expected error for truncated data.")
+	}
+	if err := g.UnmarshalBinary([]byte{0x01, 0x01, 0x02}); err == nil {
+		t.Errorf("Expected error for reducible polynomial.")
+	}
+	if err := g.UnmarshalBinary([]byte{0x02, 0x00, 0x02}); err == nil {
+		t.Errorf("Expected error for high-degree polynomial.")
+	}
+	corrupted := append([]byte{}, data...)
+	corrupted[2] = 0x01
+	if err := g.UnmarshalBinary(corrupted); err == nil {
+		t.Errorf("Expected error for invalid generator.")
+	}
+	corrupted = append([]byte{}, data...)
+	corrupted[10] = corrupted[11]
+	if err := g.UnmarshalBinary(corrupted); err == nil {
+		t.Errorf("Expected error for duplicate exp table entry.")
+	}
+	corrupted = append([]byte{}, data...)
+	corrupted[3], corrupted[4] = corrupted[4], corrupted[3]
+	if err := g.UnmarshalBinary(corrupted); err == nil {
+		t.Errorf("Expected error for exp table not matching generator.")
+	}
+	// A permutation of the non-zero elements that is not the powers of g.
+	corrupted = append([]byte{}, data...)
+	corrupted[13], corrupted[23] = corrupted[23], corrupted[13]
+	if err := g.UnmarshalBinary(corrupted); err == nil {
+		t.Errorf("Expected error for swapped exp table entries.")
+	}
+	// 0x11b is irreducible but 0x02 does not generate GF[2⁸] with it, so the
+	// powers of 0x02 repeat before filling the table.
+	aes := NewAESField()
+	corrupted, _ = aes.MarshalBinary()
+	corrupted[2] = 0x02
+	for i := range corrupted[3:] {
+		corrupted[3+i] = byte(aes.Pow(0x02, i))
+	}
+	if err := g.UnmarshalBinary(corrupted); err == nil {
+		t.Errorf("Expected error for non-primitive generator.")
+	}
+}
+
+func TestNumMarshalText(t *testing.T) {
+	for i := uint(0); i < 256; i++ {
+		n := Num(i)
+		text, err := n.MarshalText()
+		if err != nil {
+			t.Errorf("MarshalText(%v): got error %v", n, err)
+		}
+		if string(text) != n.String() {
+			t.Errorf("MarshalText(%v): got %q.", n, text)
+		}
+		var m Num
+		if err := m.UnmarshalText(text); err != nil {
+			t.Errorf("UnmarshalText(%q): got error %v", text, err)
+		}
+		if m != n {
+			t.Errorf("UnmarshalText(%q): expected %v, got %v.", text, n, m)
+		}
+	}
+	// Round trip through a text-based encoding.
+	p := Polynomial{0x00, 0x01, 0x17, 0xff}
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Errorf("json.Marshal(%v): got error %v", p, err)
+	}
+	if string(data) != `["0","1","10111","11111111"]` {
+		t.Errorf("json.Marshal(%v): got %s.", p, data)
+	}
+	var q Polynomial
+	if err := json.Unmarshal(data, &q); err != nil {
+		t.Errorf("json.Unmarshal(%s): got error %v", data, err)
+	}
+	if q.String() != p.String() || len(q) != len(p) {
+		t.Errorf("json.Unmarshal(%s): expected %v, got %v.", data, p, q)
+	}
+	for _, text := range []string{"", "2", "100000000", "0x1f"} {
+		n := Num(0x17)
+		if err := n.UnmarshalText([]byte(text)); err == nil {
+			t.Errorf("UnmarshalText(%q): expected error, got %v.", text, n)
+		}
+		if n != 0x17 {
+			t.Errorf("UnmarshalText(%q) modified its receiver on error.", text)
+		}
+	}
+	if err := json.Unmarshal([]byte(`["1","100000000"]`), &q); err == nil {
+		t.Errorf("json.Unmarshal with out-of-range text: expected error.")
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gf256
+
+import "fmt"
+
+// ErasureCoder computes parity shards for a set of equally sized data
+// shards and reconstructs missing shards from any dataShards of them.
+type ErasureCoder struct {
+	f            *Field
+	dataShards   int
+	parityShards int
+	// encoding is a (dataShards+parityShards)×dataShards matrix whose
+	// first dataShards rows form the identity matrix and whose remaining
+	// rows form a Cauchy matrix, so that any dataShards rows are invertible.
+	encoding Matrix
+}
+
+// NewErasureCoder returns an ErasureCoder for the given number of data and
+// parity shards, or an error if the numbers are out of range. Since shards
+// hold bytes, f must be a GF[2⁸] field.
+func (f *Field) NewErasureCoder(dataShards, parityShards int) (*ErasureCoder, error) {
+	if f.m != 8 {
+		return nil, fmt.Errorf("NewErasureCoder: byte shards require GF[2⁸], not GF(2^%d).", f.m)
+	}
+	if dataShards < 1 || parityShards < 0 {
+		return nil, fmt.Errorf("NewErasureCoder: invalid number of shards %d+%d.", dataShards, parityShards)
+	}
+	cauchy, err := f.Cauchy(parityShards, dataShards)
+	if err != nil {
+		return nil, fmt.Errorf("NewErasureCoder: too many shards %d+%d.", dataShards, parityShards)
+	}
+	encoding := f.NewMatrix(dataShards+parityShards, dataShards)
+	for i := 0; i < dataShards; i++ {
+		encoding.Set(i, i, f.One())
+	}
+	for i := 0; i < parityShards; i++ {
+		for j := 0; j < dataShards; j++ {
+			encoding.Set(dataShards+i, j, cauchy.At(i, j))
+		}
+	}
+	return &ErasureCoder{
+		f:            f,
+		dataShards:   dataShards,
+		parityShards: parityShards,
+		encoding:     encoding,
+	}, nil
+}
+
+// Encode computes the parity shards shards[dataShards:] from the data
+// shards shards[:dataShards]. All data shards must have the same length;
+// parity shards that are nil are allocated.
+func (e *ErasureCoder) Encode(shards [][]byte) error {
+	size, err := e.checkShards(shards, nil)
+	if err != nil {
+		return err
+	}
+	for i := e.dataShards; i < len(shards); i++ {
+		if shards[i] == nil {
+			shards[i] = make([]byte, size)
+		}
+		e.encodeShard(i, shards)
+	}
+	return nil
+}
+
+// Reconstruct rebuilds every shard i with present[i] false from the shards
+// that are present, or returns an error if fewer than dataShards shards are
+// present. Missing shards are resliced to the length of the present shards,
+// reusing their storage, and allocated if they have too small capacity.
+func (e *ErasureCoder) Reconstruct(shards [][]byte, present []bool) error {
+	if len(present) != len(shards) {
+		return fmt.Errorf("Reconstruct: %d shards but %d presence flags.", len(shards), len(present))
+	}
+	size, err := e.checkShards(shards, present)
+	if err != nil {
+		return err
+	}
+	// Pick the first dataShards present shards and invert the
+	// corresponding rows of the encoding matrix.
+	var rows []int
+	for i := range shards {
+		if present[i] && len(rows) < e.dataShards {
+			rows = append(rows, i)
+		}
+	}
+	if len(rows) < e.dataShards {
+		return fmt.Errorf("Reconstruct: only %d of %d required shards present.", len(rows), e.dataShards)
+	}
+	f := e.f
+	sub := f.NewMatrix(e.dataShards, e.dataShards)
+	for i, row := range rows {
+		for j := 0; j < e.dataShards; j++ {
+			sub.Set(i, j, e.encoding.At(row, j))
+		}
+	}
+	decoding, err := f.MatInvert(sub)
+	if err != nil {
+		return err // Cannot happen: any dataShards rows are invertible.
+	}
+	for i := 0; i < e.dataShards; i++ {
+		if present[i] {
+			continue
+		}
+		shards[i] = resizeShard(shards[i], size)
+		for k := range shards[i] {
+			shards[i][k] = 0
+		}
+		for j, row := range rows {
+			f.mulAddBytes(decoding.At(i, j), shards[row], shards[i])
+		}
+	}
+	for i := e.dataShards; i < len(shards); i++ {
+		if !present[i] {
+			shards[i] = resizeShard(shards[i], size)
+			e.encodeShard(i, shards)
+		}
+	}
+	return nil
+}
+
+// checkShards verifies the number of shards and that all shards that are
+// present have the same length, and returns that length. A nil present
+// means that the data shards are present and the parity shards are not.
+func (e *ErasureCoder) checkShards(shards [][]byte, present []bool) (int, error) {
+	if len(shards) != e.dataShards+e.parityShards {
+		return 0, fmt.Errorf("Expected %d shards, got %d.", e.dataShards+e.parityShards, len(shards))
+	}
+	size := -1
+	for i, shard := range shards {
+		if (present == nil && i >= e.dataShards) || (present != nil && !present[i]) {
+			continue
+		}
+		if size == -1 {
+			size = len(shard)
+		}
+		if len(shard) != size {
+			return 0, fmt.Errorf("Shard %d has length %d, expected %d.", i, len(shard), size)
+		}
+	}
+	if size == -1 {
+		size = 0
+	}
+	for i := e.dataShards; present == nil && i < len(shards); i++ {
+		if shards[i] != nil && len(shards[i]) != size {
+			return 0, fmt.Errorf("Shard %d has length %d, expected %d.", i, len(shards[i]), size)
+		}
+	}
+	return size, nil
+}
+
+// resizeShard returns shard resliced to size, or a new slice if shard has
+// too small capacity.
+func resizeShard(shard []byte, size int) []byte {
+	if cap(shard) < size {
+		return make([]byte, size)
+	}
+	return shard[:size]
+}
+
+// encodeShard computes the parity shard i from the data shards.
+func (e *ErasureCoder) encodeShard(i int, shards [][]byte) {
+	out := shards[i]
+	for k := range out {
+		out[k] = 0
+	}
+	for j := 0; j < e.dataShards; j++ {
+		e.f.mulAddBytes(e.encoding.At(i, j), shards[j], out)
+	}
+}
+
+// mulAddBytes computes out[i] = out[i] + c×in[i] for every i, treating the
+// bytes as elements of f.
+func (f *Field) mulAddBytes(c Num, in, out []byte) {
+	var table [256]byte
+	for x := range table {
+		table[x] = byte(f.Mul(c, Num(x)))
+	}
+	for i, x := range in {
+		out[i] = out[i] ^ table[x]
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gf256
+
+import "bytes"
+import "fmt"
+import "math/rand"
+import "testing"
+
+func ExampleErasureCoder() {
+	f, _ := NewField(0x11d, 0x2)
+	e, _ := f.NewErasureCoder(3, 2)
+	shards := [][]byte{[]byte("abc"), []byte("def"), []byte("ghi"), nil, nil}
+	e.Encode(shards)
+	shards[0], shards[2] = nil, nil
+	e.Reconstruct(shards, []bool{false, true, false, true, true})
+	fmt.Printf("%s %s %s\n", shards[0], shards[1], shards[2])
+	// Output:
+	// abc def ghi
+}
+
+func TestErasureCoder(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	dataShards, parityShards := 5, 3
+	e, err := f.NewErasureCoder(dataShards, parityShards)
+	if err != nil {
+		t.Errorf("NewErasureCoder: got error %v", err)
+		return
+	}
+	random := rand.New(rand.NewSource(1))
+	original := make([][]byte, dataShards+parityShards)
+	for i := 0; i < dataShards; i++ {
+		original[i] = make([]byte, 100)
+		random.Read(original[i])
+	}
+	if err := e.Encode(original); err != nil {
+		t.Errorf("Encode: got error %v", err)
+		return
+	}
+	// Try every way of dropping shards.
+	for mask := 0; mask < 1<<uint(len(original)); mask++ {
+		shards := make([][]byte, len(original))
+		present := make([]bool, len(original))
+		missing := 0
+		for i := range shards {
+			if mask&(1<<uint(i)) != 0 {
+				missing++
+				continue
+			}
+			shards[i] = append([]byte{}, original[i]...)
+			present[i] = true
+		}
+		err := e.Reconstruct(shards, present)
+		if missing > parityShards {
+			if err == nil {
+				t.Errorf("Reconstruct with %d missing shards: expected error.", missing)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Reconstruct with shards %v present: got error %v", present, err)
+			continue
+		}
+		for i := range shards {
+			if !bytes.Equal(shards[i], original[i]) {
+				t.Errorf("Reconstruct with shards %v present: shard %d differs.", present, i)
+			}
+		}
+	}
+}
+
+func TestReconstructReusesShards(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	e, err := f.NewErasureCoder(3, 2)
+	if err != nil {
+		t.Errorf("NewErasureCoder: got error %v", err)
+		return
+	}
+	random := rand.New(rand.NewSource(1))
+	original := make([][]byte, 5)
+	for i := 0; i < 3; i++ {
+		original[i] = make([]byte, 10)
+		random.Read(original[i])
+	}
+	if err := e.Encode(original); err != nil {
+		t.Errorf("Encode: got error %v", err)
+		return
+	}
+	// A data and a parity shard are missing. Their buffers hold garbage;
+	// one is short but has enough capacity and is reused, the other is too
+	// small and is replaced.
+	shards := make([][]byte, 5)
+	for i := range shards {
+		shards[i] = append([]byte{}, original[i]...)
+	}
+	reused := make([]byte, 4, 10)
+	for k := range reused[:cap(reused)] {
+		reused[:cap(reused)][k] = 0xff
+	}
+	shards[1], shards[4] = reused, []byte{0xff}
+	present := []bool{true, false, true, true, false}
+	if err := e.Reconstruct(shards, present); err != nil {
+		t.Errorf("Reconstruct: got error %v", err)
+		return
+	}
+	if &shards[1][0] != &reused[0] {
+		t.Errorf("Reconstruct: expected missing shard buffer to be reused.")
+	}
+	for i := range shards {
+		if !bytes.Equal(shards[i], original[i]) {
+			t.Errorf("Reconstruct: shard %d differs.", i)
+		}
+	}
+}
+
+func TestErasureCoderErrors(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	if _, err := f.NewErasureCoder(0, 2); err == nil {
+		t.Errorf("Expected error for zero data shards.")
+	}
+	if _, err := f.NewErasureCoder(200, 57); err == nil {
+		t.Errorf("Expected error for too many shards.")
+	}
+	e, _ := f.NewErasureCoder(2, 1)
+	if err := e.Encode([][]byte{{1}, {2}}); err == nil {
+		t.Errorf("Expected error for wrong number of shards.")
+	}
+	if err := e.Encode([][]byte{{1}, {2, 3}, nil}); err == nil {
+		t.Errorf("Expected error for shards of different lengths.")
+	}
+	if err := e.Reconstruct([][]byte{{1}, {2}, {3}}, []bool{true, true}); err == nil {
+		t.Errorf("Expected error for wrong number of presence flags.")
+	}
+}
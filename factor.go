@@ -0,0 +1,268 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gf256
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+	"sort"
+)
+
+// DegreeFactor is the product of all monic irreducible factors of a given
+// degree of some polynomial. See DistinctDegreeFactorization.
+type DegreeFactor struct {
+	// Degree is the degree of each irreducible factor of Factor.
+	Degree int
+	// Factor is the monic product of all irreducible factors of Degree.
+	Factor Polynomial
+}
+
+// DistinctDegreeFactorization splits the square-free polynomial p into
+// products of irreducible factors of equal degree, in order of increasing
+// degree. The factors are monic, and only degrees that occur are listed.
+// It returns an error if p is the zero polynomial or is not square-free;
+// see SquareFreeFactorization.
+func (f *Field) DistinctDegreeFactorization(p Polynomial) ([]DegreeFactor, error) {
+	p, err := f.MakeMonic(p)
+	if err != nil {
+		return nil, fmt.Errorf("DistinctDegreeFactorization: %w", err)
+	}
+	if f.Degree(p) > 0 {
+		if g, _ := f.GCD(p, f.Derivative(p)); f.Degree(g) > 0 {
+			return nil, fmt.Errorf("DistinctDegreeFactorization: %v is not square-free.", p)
+		}
+	}
+	// The code below uses that x^(q^d) - x, where q is the size of the
+	// field, is the product of all monic irreducible polynomials whose
+	// degree divides d. Factors of lower degree have been removed from p
+	// by the time d is reached, so gcd(p, x^(q^d) - x) collects exactly
+	// the factors of degree d.
+	var factors []DegreeFactor
+	x := Polynomial{f.Zero(), f.One()}
+	h := x // h == x^(q^d) mod p.
+	for d := 1; f.Degree(p) >= 2*d; d++ {
+		h, _ = f.PowModPolynomial(h, f.Cardinality(), p)
+		g, _ := f.GCD(p, f.AddPolynomials(h, x))
+		if f.Degree(g) > 0 {
+			factors = append(factors, DegreeFactor{Degree: d, Factor: g})
+			p, _ = f.QuotientPolynomial(p, g)
+			p = f.Normalize(p)
+			h, _ = f.ModPolynomial(h, p)
+		}
+	}
+	// Whatever remains has no factors of degree up to half its own degree,
+	// so it is irreducible.
+	if d := f.Degree(p); d > 0 {
+		factors = append(factors, DegreeFactor{Degree: d, Factor: p})
+	}
+	return factors, nil
+}
+
+// SquareFreeFactorization returns square-free, pairwise coprime, monic
+// polynomials s such that p is a constant times the product of s[i]^(i+1)
+// over all i. In other words, s[i] is the product of the irreducible factors
+// of p that occur with multiplicity exactly i+1, or one if there are none.
+// The last element of s has positive degree, and s is empty if p is a non-zero
+// constant. SquareFreeFactorization returns an error if p is the zero
+// polynomial.
+func (f *Field) SquareFreeFactorization(p Polynomial) ([]Polynomial, error) {
+	p, err := f.MakeMonic(p)
+	if err != nil {
+		return nil, fmt.Errorf("SquareFreeFactorization: %w", err)
+	}
+	factors := make(map[int]Polynomial)
+	f.squareFree(p, 1, factors)
+	var s []Polynomial
+	for i := 1; len(factors) > 0; i++ {
+		factor, ok := factors[i]
+		if !ok {
+			factor = Polynomial{f.One()}
+		}
+		delete(factors, i)
+		s = append(s, factor)
+	}
+	return s, nil
+}
+
+// squareFree multiplies factors[i×multiplicity] by the product of the
+// irreducible factors of the monic polynomial p of multiplicity i, for
+// every i. Entries for polynomials of degree zero are not added.
+func (f *Field) squareFree(p Polynomial, multiplicity int, factors map[int]Polynomial) {
+	// The code below implements Yun's algorithm adapted to characteristic
+	// two, where the derivative of a square is zero. Factors of p with odd
+	// multiplicity are found by repeated GCDs, and what remains is a square
+	// whose square root is handled recursively.
+	c, _ := f.GCD(p, f.Derivative(p))
+	w, _ := f.QuotientPolynomial(p, c)
+	w = f.Normalize(w)
+	for i := 1; f.Degree(w) > 0; i++ {
+		y, _ := f.GCD(w, c)
+		factor, _ := f.QuotientPolynomial(w, y)
+		if factor = f.Normalize(factor); f.Degree(factor) > 0 {
+			j := i * multiplicity
+			if previous, ok := factors[j]; ok {
+				factor = f.Normalize(f.MultiplyPolynomials(previous, factor))
+			}
+			factors[j] = factor
+		}
+		w = y
+		c, _ = f.QuotientPolynomial(c, y)
+		c = f.Normalize(c)
+	}
+	if f.Degree(c) > 0 {
+		// Only even powers of x occur in c, and squaring is additive in
+		// characteristic two, so c is the square of the polynomial whose
+		// coefficients are the square roots of those of c.
+		root := make(Polynomial, len(c)/2+1)
+		for i := range root {
+			root[i] = f.Sqrt(c[2*i])
+		}
+		f.squareFree(root, 2*multiplicity, factors)
+	}
+}
+
+// RootsCZ returns, in ascending order, the distinct roots of p in the field
+// f, like Roots, or an error if p is the zero polynomial or reading from rand
+// fails. Rather than evaluating p everywhere, RootsCZ uses the probabilistic
+// Cantor-Zassenhaus algorithm, which is faster for polynomials of high
+// degree. Randomness is read from rand but does not affect the result.
+func (f *Field) RootsCZ(p Polynomial, rand io.Reader) ([]Num, error) {
+	p, err := f.MakeMonic(p)
+	if err != nil {
+		return nil, fmt.Errorf("RootsCZ: %w", err)
+	}
+	// The roots of p are the roots of gcd(p, x^q - x), where q is the size
+	// of the field, which is the product of the distinct linear factors of p.
+	x := Polynomial{f.Zero(), f.One()}
+	h, _ := f.PowModPolynomial(x, f.Cardinality(), p)
+	g, _ := f.GCD(p, f.AddPolynomials(h, x))
+	var roots []Num
+	if err := f.splitLinearFactors(g, rand, &roots); err != nil {
+		return nil, fmt.Errorf("RootsCZ: reading randomness: %v", err)
+	}
+	sort.Slice(roots, func(i, j int) bool { return roots[i] < roots[j] })
+	return roots, nil
+}
+
+// splitLinearFactors appends the roots of g, a monic product of distinct
+// linear factors, to roots.
+func (f *Field) splitLinearFactors(g Polynomial, rand io.Reader, roots *[]Num) error {
+	switch f.Degree(g) {
+	case 0:
+		return nil
+	case 1:
+		// g == x + r, and the root is r since subtraction is addition.
+		*roots = append(*roots, g[0])
+		return nil
+	}
+	// The code below splits g using the trace map Tr(y) = y + y² + … +
+	// y^(2^(m-1)), which is zero for exactly half of the field elements.
+	// For random a, gcd(g, Tr(a×x)) collects the roots r of g with
+	// Tr(a×r) == 0, which is a proper factor with probability at least 1/2.
+	for {
+		random, err := f.randomElements(rand, 1)
+		if err != nil {
+			return err
+		}
+		y := Polynomial{f.Zero(), random[0]}
+		trace := y
+		for i := uint(1); i < f.m; i++ {
+			y, _ = f.ModPolynomial(f.MultiplyPolynomials(y, y), g)
+			trace = f.AddPolynomials(trace, y)
+		}
+		h, err := f.GCD(g, trace)
+		if err != nil || f.Degree(h) == 0 || f.Degree(h) == f.Degree(g) {
+			continue // Trace is zero, or the split is trivial.
+		}
+		quot, _ := f.QuotientPolynomial(g, h)
+		if err := f.splitLinearFactors(h, rand, roots); err != nil {
+			return err
+		}
+		return f.splitLinearFactors(f.Normalize(quot), rand, roots)
+	}
+}
+
+// PolynomialOrder returns the multiplicative order of x modulo m: the
+// smallest k > 0 such that x^k ≡ 1 (mod m). It returns an error if m has
+// degree zero, if x and m are not coprime, which happens exactly when the
+// constant term of m is zero, or if the order is too large to represent.
+func (f *Field) PolynomialOrder(m Polynomial) (int, error) {
+	m = f.Normalize(m)
+	if len(m) < 2 || m[0] == f.Zero() {
+		return 0, fmt.Errorf("PolynomialOrder: x is not invertible modulo %v.", m)
+	}
+	// The order of x modulo an irreducible factor of degree d divides
+	// q^d-1, where q is the size of the field, and the order modulo the
+	// e-th power of that factor divides (q^d-1)×2^t, where 2^t ≥ e. The
+	// least common multiple of those bounds is a multiple of the order.
+	factors, _ := f.SquareFreeFactorization(m)
+	bound := 1
+	for i, s := range factors {
+		if f.Degree(s) == 0 {
+			continue
+		}
+		ddf, _ := f.DistinctDegreeFactorization(s)
+		for _, factor := range ddf {
+			shift := bits.Len(uint(i))
+			ok := factor.Degree*int(f.m)+shift < bits.UintSize-1
+			if ok {
+				bound, ok = lcm(bound, (1<<(factor.Degree*int(f.m))-1)<<shift)
+			}
+			if !ok {
+				return 0, fmt.Errorf("PolynomialOrder: order modulo %v is too large.", m)
+			}
+		}
+	}
+	// Remove prime factors from the bound for as long as x raised to the
+	// reduced bound remains one.
+	x := Polynomial{f.Zero(), f.One()}
+	order := bound
+	for _, p := range primeFactors(bound) {
+		for order%p == 0 {
+			y, _ := f.PowModPolynomial(x, order/p, m)
+			if len(y) != 1 || y[0] != f.One() {
+				break
+			}
+			order = order / p
+		}
+	}
+	return order, nil
+}
+
+// lcm returns the least common multiple of the positive integers a and b,
+// and false if it overflows an int.
+func lcm(a, b int) (int, bool) {
+	hi, lo := bits.Mul(uint(a/gcd(a, b)), uint(b))
+	return int(lo), hi == 0 && lo <= math.MaxInt
+}
+
+// primeFactors returns the distinct prime factors of n in increasing order.
+func primeFactors(n int) []int {
+	var factors []int
+	for p := 2; p*p <= n; p++ {
+		if n%p == 0 {
+			factors = append(factors, p)
+			for n%p == 0 {
+				n = n / p
+			}
+		}
+	}
+	if n > 1 {
+		factors = append(factors, n)
+	}
+	return factors
+}
@@ -0,0 +1,283 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gf256
+
+import "bytes"
+import "errors"
+import "fmt"
+import "math/rand"
+import "testing"
+
+// irreducibleFactors returns n distinct monic irreducible polynomials of the
+// given degree over f, which must be 2 or 3 so that having no roots implies
+// irreducibility.
+func irreducibleFactors(f *Field, degree, n int) []Polynomial {
+	var factors []Polynomial
+	for c := 1; c < 256 && len(factors) < n; c++ {
+		p := make(Polynomial, degree+1)
+		p[0], p[1], p[degree] = Num(c), f.One(), f.One()
+		if len(f.Roots(p)) == 0 {
+			factors = append(factors, p)
+		}
+	}
+	return factors
+}
+
+// product returns the product of all polynomials in ps.
+func product(f *Field, ps ...Polynomial) Polynomial {
+	result := Polynomial{f.One()}
+	for _, p := range ps {
+		result = f.Normalize(f.MultiplyPolynomials(result, p))
+	}
+	return result
+}
+
+func ExampleField_DistinctDegreeFactorization() {
+	f, _ := NewField(0x11d, 0x2)
+	// (x+1)(x+10)(x²+x+100000) where the quadratic has no roots.
+	p := product(f, Polynomial{0x01, 0x01}, Polynomial{0x02, 0x01}, Polynomial{0x20, 0x01, 0x01})
+	factors, _ := f.DistinctDegreeFactorization(p)
+	for _, factor := range factors {
+		fmt.Println(factor.Degree, factor.Factor)
+	}
+	// Output:
+	// 1 x^2 + 11 x + 10
+	// 2 x^2 + x + 100000
+}
+
+func TestDistinctDegreeFactorization(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	quadratics := irreducibleFactors(f, 2, 2)
+	cubics := irreducibleFactors(f, 3, 2)
+	if len(quadratics) != 2 || len(cubics) != 2 {
+		t.Errorf("Could not find irreducible test polynomials.")
+		return
+	}
+	linear := []Polynomial{{0x01, 0x01}, {0x02, 0x01}, {0x53, 0x01}}
+	testData := []struct {
+		factors  []Polynomial
+		expected map[int][]Polynomial
+	}{
+		{nil, map[int][]Polynomial{}},
+		{linear[:1], map[int][]Polynomial{1: linear[:1]}},
+		{linear, map[int][]Polynomial{1: linear}},
+		{quadratics[:1], map[int][]Polynomial{2: quadratics[:1]}},
+		{quadratics, map[int][]Polynomial{2: quadratics}},
+		{append(append([]Polynomial{}, linear[:2]...), quadratics...),
+			map[int][]Polynomial{1: linear[:2], 2: quadratics}},
+		{append(append([]Polynomial{}, cubics...), linear[2]),
+			map[int][]Polynomial{1: linear[2:], 3: cubics}},
+		{[]Polynomial{linear[0], quadratics[1], cubics[0]},
+			map[int][]Polynomial{1: linear[:1], 2: quadratics[1:], 3: cubics[:1]}},
+	}
+	for _, data := range testData {
+		// Scale by a constant to check that the result is monic anyway.
+		p := f.ScalePolynomial(0x8e, product(f, data.factors...))
+		factors, err := f.DistinctDegreeFactorization(p)
+		if err != nil {
+			t.Errorf("DistinctDegreeFactorization(%v): unexpected error: %v", p, err)
+			continue
+		}
+		if len(factors) != len(data.expected) {
+			t.Errorf("DistinctDegreeFactorization(%v): unexpected result %v.", p, factors)
+			continue
+		}
+		for i, factor := range factors {
+			if i > 0 && factors[i-1].Degree >= factor.Degree {
+				t.Errorf("DistinctDegreeFactorization(%v): degrees not increasing: %v.", p, factors)
+			}
+			expected := product(f, data.expected[factor.Degree]...)
+			if factor.Factor.String() != expected.String() {
+				t.Errorf("DistinctDegreeFactorization(%v): degree %d: expected %v, got %v.", p, factor.Degree, expected, factor.Factor)
+			}
+		}
+	}
+	if _, err := f.DistinctDegreeFactorization(Polynomial{0x00}); !errors.Is(err, ErrZeroPolynomial) {
+		t.Errorf("Expected ErrZeroPolynomial, got %v.", err)
+	}
+	if _, err := f.DistinctDegreeFactorization(product(f, linear[0], linear[0], linear[1])); err == nil {
+		t.Errorf("Expected error return value from polynomial that is not square-free.")
+	}
+}
+
+func ExampleField_SquareFreeFactorization() {
+	f, _ := NewField(0x11d, 0x2)
+	// (x+1)(x+10)³
+	p := product(f, Polynomial{0x01, 0x01}, Polynomial{0x02, 0x01}, Polynomial{0x02, 0x01}, Polynomial{0x02, 0x01})
+	s, _ := f.SquareFreeFactorization(p)
+	fmt.Println(s)
+	// Output:
+	// [x + 1 1 x + 10]
+}
+
+func TestSquareFreeFactorization(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	quadratics := irreducibleFactors(f, 2, 2)
+	if len(quadratics) != 2 {
+		t.Errorf("Could not find irreducible test polynomials.")
+		return
+	}
+	a, b, c := Polynomial{0x01, 0x01}, Polynomial{0x02, 0x01}, Polynomial{0x53, 0x01}
+	q1, q2 := quadratics[0], quadratics[1]
+	one := Polynomial{0x01}
+	testData := []struct {
+		multiplicities []Polynomial // Factor of multiplicity i+1 at index i.
+	}{
+		{nil},
+		{[]Polynomial{a}},
+		{[]Polynomial{product(f, a, b, q1)}},
+		{[]Polynomial{one, a}},
+		{[]Polynomial{one, q1}},
+		{[]Polynomial{a, b}},
+		{[]Polynomial{a, one, b}},
+		{[]Polynomial{one, one, one, a}},
+		{[]Polynomial{q1, one, one, one, one, b}},
+		{[]Polynomial{c, a, q2, b, one, one, q1}},
+		{[]Polynomial{product(f, a, q1), product(f, b, q2), one, c}},
+	}
+	for _, data := range testData {
+		p := Polynomial{0xca}
+		for i, factor := range data.multiplicities {
+			p = product(f, p, f.PowPolynomial(factor, i+1))
+		}
+		s, err := f.SquareFreeFactorization(p)
+		if err != nil {
+			t.Errorf("SquareFreeFactorization(%v): unexpected error: %v", p, err)
+			continue
+		}
+		if fmt.Sprint(s) != fmt.Sprint(data.multiplicities) {
+			t.Errorf("SquareFreeFactorization(%v): expected %v, got %v.", p, data.multiplicities, s)
+		}
+		// Each part is square-free.
+		for _, part := range s {
+			if f.Degree(part) > 0 {
+				if g, _ := f.GCD(part, f.Derivative(part)); f.Degree(g) > 0 {
+					t.Errorf("SquareFreeFactorization(%v): %v is not square-free.", p, part)
+				}
+			}
+		}
+	}
+	if _, err := f.SquareFreeFactorization(nil); !errors.Is(err, ErrZeroPolynomial) {
+		t.Errorf("Expected ErrZeroPolynomial, got %v.", err)
+	}
+}
+
+func ExampleField_RootsCZ() {
+	f, _ := NewField(0x11d, 0x2)
+	p := product(f, Polynomial{0x11, 0x01}, Polynomial{0x03, 0x01}, Polynomial{0x00, 0x01})
+	roots, _ := f.RootsCZ(p, rand.New(rand.NewSource(1)))
+	fmt.Println(roots)
+	// Output:
+	// [0 11 10001]
+}
+
+func TestRootsCZ(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	random := rand.New(rand.NewSource(1))
+	quadratics := irreducibleFactors(f, 2, 1)
+	for _, numRoots := range []int{0, 1, 2, 5, 20, 100, 256} {
+		for _, extra := range []Polynomial{{0x01}, {0x02, 0x01}, quadratics[0]} {
+			p := extra
+			for _, i := range random.Perm(256)[:numRoots] {
+				p = product(f, p, Polynomial{Num(i), 0x01})
+			}
+			expected := f.Roots(p)
+			actual, err := f.RootsCZ(p, random)
+			if err != nil {
+				t.Errorf("RootsCZ(%v): unexpected error: %v", p, err)
+				continue
+			}
+			if fmt.Sprint(expected) != fmt.Sprint(actual) {
+				t.Errorf("RootsCZ(%v): expected %v, got %v.", p, expected, actual)
+			}
+		}
+	}
+	// Roots in a field other than GF[2⁸].
+	g, err := NewFieldGF2m(12, 0x1053, 0x2)
+	if err != nil {
+		t.Errorf("Could not create GF(2^12): %v.", err)
+		return
+	}
+	p := product(g, Polynomial{0x123, 0x01}, Polynomial{0xfff, 0x01}, Polynomial{0x00, 0x01}, Polynomial{0x456, 0x01})
+	if roots, err := g.RootsCZ(p, random); err != nil || fmt.Sprint(roots) != fmt.Sprint(g.Roots(p)) {
+		t.Errorf("GF(2^12): RootsCZ(%v): unexpected result %v, %v.", p, roots, err)
+	}
+	if _, err := f.RootsCZ(Polynomial{0x00}, random); !errors.Is(err, ErrZeroPolynomial) {
+		t.Errorf("Expected ErrZeroPolynomial, got %v.", err)
+	}
+	if _, err := f.RootsCZ(product(f, Polynomial{0x01, 0x01}, Polynomial{0x02, 0x01}), bytes.NewReader(nil)); err == nil {
+		t.Errorf("Expected error return value from empty source of randomness.")
+	}
+}
+
+func TestPolynomialOrder(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	one := Polynomial{f.One()}
+	g3 := f.Exp(85) // An element of order 3.
+	testData := []struct {
+		m        Polynomial
+		expected int
+	}{
+		// x⁸+x⁴+x³+x²+1 splits over GF[2⁸] into factors x-α^(2^i), where
+		// every α^(2^i) has order 255.
+		{Polynomial{0x01, 0x00, 0x01, 0x01, 0x01, 0x00, 0x00, 0x00, 0x01}, 255},
+		{Polynomial{0x01, 0x01}, 1},
+		{Polynomial{0x01, 0x00, 0x01}, 2}, // (x+1)²
+		{product(f, Polynomial{g3, 0x01}, Polynomial{0x01, 0x01}, Polynomial{0x01, 0x01}), 6},
+		{product(f, Polynomial{g3, 0x01}, Polynomial{0x02, 0x01}), 255},
+		{Polynomial{0x20, 0x01, 0x01}, 13107}, // Irreducible; 13107 divides 256²-1.
+		{product(f, Polynomial{0x20, 0x01, 0x01}, Polynomial{g3, 0x01}, Polynomial{g3, 0x01}), 26214},
+	}
+	for _, data := range testData {
+		actual, err := f.PolynomialOrder(data.m)
+		if err != nil {
+			t.Errorf("PolynomialOrder(%v): unexpected error %v.", data.m, err)
+			continue
+		}
+		if actual != data.expected {
+			t.Errorf("PolynomialOrder(%v): expected %d, actual %d.", data.m, data.expected, actual)
+		}
+		// Confirm by stepping through the powers of x.
+		y := one
+		for k := 1; k <= actual; k++ {
+			y, _ = f.ModPolynomial(f.ShiftPolynomial(y, 1), data.m)
+			if isOne := len(y) == 1 && y[0] == f.One(); isOne != (k == actual) {
+				t.Errorf("PolynomialOrder(%v) == %d, but x^%d ≡ %v.", data.m, actual, k, y)
+				break
+			}
+		}
+	}
+	for _, m := range []Polynomial{nil, {0x05}, {0x00, 0x01}, {0x00, 0x02, 0x01}} {
+		if _, err := f.PolynomialOrder(m); err == nil {
+			t.Errorf("PolynomialOrder(%v): expected error, got nil.", m)
+		}
+	}
+}
@@ -13,23 +13,49 @@
 // limitations under the License.
 
 // Package gf256 implements arithmetic over the finite field GF[2⁸] as well as
-// over the polynomial ring with coefficients in GF[2⁸].
+// over the polynomial ring with coefficients in GF[2⁸]. Fields GF(2^m) of
+// other sizes can be created using NewFieldGF2m.
+//
+// A *Field is immutable once created, apart from the lazily built
+// multiplication table of EnableMulTable and the replacement performed by
+// UnmarshalBinary, and is safe for concurrent use by multiple goroutines
+// as long as UnmarshalBinary is not called concurrently.
 package gf256
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// ErrLogZero is returned when taking the logarithm of zero.
+	ErrLogZero = errors.New("gf256: log of zero")
+	// ErrInvZero is returned when taking the multiplicative inverse of zero.
+	ErrInvZero = errors.New("gf256: inverse of zero")
+	// ErrDivideByZero is returned when dividing a field element by zero.
+	ErrDivideByZero = errors.New("gf256: division by zero")
+	// ErrOutOfRange is returned when a Num is not an element of the field.
+	ErrOutOfRange = errors.New("gf256: number out of range")
+)
 
 // Num is a bit-vector representation of the polynomial used to represent
 // numbers in GF[2⁸]. Concretely, values of Num will be unsigned integers
-// between 0 and 255.
+// between 0 and 255, or between 0 and 2^m-1 for GF(2^m).
 type Num uint
 
 // Irreducible is a bit-vector representation of the irreducible polynomial
-// used to define GF[2⁸]. This will be an unsigned integer with the ninth bit
-// set and no higher-order bits set.
+// used to define GF[2⁸], or GF(2^m) in general. Bit m is its highest set
+// bit, which for GF[2⁸] is the ninth bit as in 0x11d, and for GF(2¹⁶) is
+// bit 16 as in 0x1100b.
 type Irreducible uint
 
-// Field represents an instantiation of GF[2⁸].
+// Field represents an instantiation of GF[2⁸], or more generally of GF(2^m).
 type Field struct {
+	// m is the degree of poly; the field has 2^m elements.
+	m uint
 	// poly is a bit-vector representation of the irreducible
 	// polynomial in Z₂[x] which define the irreducible congruence
 	// used to define the field. Bit i in the bit-vector
@@ -42,10 +68,21 @@ type Field struct {
 	// A common choice is x, which corresponds to the bit-vector
 	// 10, or 2 in decimal.
 	g Num
-	// expTable[i] == g^i is built in NewField.
-	expTable [255]Num
-	// logtable[i] == log_g i is built in NewField; logtable[g^i] == i.
-	logTable [256]int
+	// expTable[i] == g^i is built in NewFieldGF2m and has 2^m-1 entries.
+	expTable []Num
+	// logtable[i] == log_g i is built in NewFieldGF2m; logtable[g^i] == i.
+	// It has 2^m entries.
+	logTable []int
+	// squareTable[i] == i×i is built in NewFieldGF2m and has 2^m entries.
+	squareTable []Num
+	// zechTable[i] == log_g(1+g^i) is built in NewFieldGF2m and has 2^m-1
+	// entries; zechTable[0] == -1 since 1+g^0 == 0 has no logarithm.
+	zechTable []int
+	// mulTable[x][y] == x×y is built by EnableMulTable; nil until then.
+	// It is built at most once, guarded by mulOnce, and loaded atomically
+	// so that Mul may run concurrently with EnableMulTable.
+	mulTable atomic.Pointer[[256][256]Num]
+	mulOnce  sync.Once
 }
 
 // Zero returns the additive zero of the field f.
@@ -68,40 +105,199 @@ func (f *Field) Polynomial() Irreducible {
 	return f.poly
 }
 
-// Exp returns the generator of the field f raised to the power x.
-func (f *Field) Exp(x int) Num {
-	x = x % 255
-	if x < 0 {
-		x = x + 255
+// Equal returns true if f and g describe the same field: the same
+// irreducible polynomial and the same generator. All tables of a field are
+// determined by these parameters.
+func (f *Field) Equal(g *Field) bool {
+	if f == nil || g == nil {
+		return f == g
+	}
+	return f.m == g.m && f.poly == g.poly && f.g == g.g
+}
+
+// Characteristic returns the characteristic of the field f, which is 2.
+func (f *Field) Characteristic() int {
+	return 2
+}
+
+// Cardinality returns the number of elements of the field f: 256 for GF[2⁸],
+// or 2^m for GF(2^m).
+func (f *Field) Cardinality() int {
+	return len(f.logTable)
+}
+
+// Subfields returns, in ascending order, the orders of all subfields of the
+// field f, including f itself. GF(2^m) has a subfield of order 2^d for every
+// divisor d of m, so Subfields returns [2 4 16 256] for GF[2⁸].
+func (f *Field) Subfields() []int {
+	var orders []int
+	for d := uint(1); d <= f.m; d++ {
+		if f.m%d == 0 {
+			orders = append(orders, 1<<d)
+		}
+	}
+	return orders
+}
+
+// InSubfield returns true if x belongs to the subfield of f with the given
+// order, i.e. if x^order == x. It returns false if order is not one of the
+// values returned by Subfields or if x is not an element of f.
+func (f *Field) InSubfield(x Num, order int) bool {
+	if !f.inRange(x) {
+		return false
+	}
+	for _, o := range f.Subfields() {
+		if o == order {
+			return f.Pow(x, order) == x
+		}
 	}
-	return f.expTable[x%255]
+	return false
+}
+
+// Exp returns the generator of the field f raised to the power x. Every int
+// is a valid exponent, since exponents are reduced modulo the order of the
+// multiplicative group.
+func (f *Field) Exp(x int) Num {
+	return f.expTable[f.reduceExponent(x)]
 }
 
 // Log returns the logarithm of x with respect to the generator of the
-// field f, or an error if x==0.
+// field f, or an error if x==0 or x is not an element of f.
 func (f *Field) Log(x Num) (int, error) {
 	if x == f.Zero() {
-		return 0, fmt.Errorf("Taking log of zero.")
+		return 0, ErrLogZero
+	}
+	if !f.inRange(x) {
+		return 0, fmt.Errorf("%w: %v", ErrOutOfRange, x)
 	}
 	return f.logTable[x], nil
 }
 
-// Inv returns the multiplicative inverse of x, or an error if x==0.
+// ExpBase returns base raised to the power n, or an error if base is not a
+// generator of the multiplicative group of the field f.
+func (f *Field) ExpBase(base Num, n int) (Num, error) {
+	if !f.IsPrimitive(base) {
+		return f.Zero(), fmt.Errorf("%v is not a generator.", base)
+	}
+	return f.Pow(base, n), nil
+}
+
+// LogBase returns the logarithm of x with respect to base, in the range 0
+// to 254, or 0 to 2^m-2 in GF(2^m). It returns an error if x==0 or base is
+// not a generator of the multiplicative group of the field f.
+func (f *Field) LogBase(base, x Num) (int, error) {
+	if !f.IsPrimitive(base) {
+		return 0, fmt.Errorf("%v is not a generator.", base)
+	}
+	logX, err := f.Log(x)
+	if err != nil {
+		return 0, err
+	}
+	// If base == g^b, then x == g^l == base^(l/b), where 1/b is the inverse
+	// of b modulo the group order; it exists since base is a generator.
+	logBase, _ := f.Log(base)
+	return f.reduceExponent(logX * modInverse(logBase, len(f.expTable))), nil
+}
+
+// ExpTable returns a copy of the exponentiation table of the field f, where
+// entry i is g^i for the generator g. The table has 255 entries, or 2^m-1
+// in GF(2^m). Modifying the copy does not affect f.
+func (f *Field) ExpTable() []Num {
+	table := make([]Num, len(f.expTable))
+	copy(table, f.expTable)
+	return table
+}
+
+// LogTable returns a copy of the logarithm table of the field f, where
+// entry g^i is i for the generator g. The table has 256 entries, or 2^m in
+// GF(2^m); entry zero is zero since zero has no logarithm. Modifying the
+// copy does not affect f.
+func (f *Field) LogTable() []int {
+	table := make([]int, len(f.logTable))
+	copy(table, f.logTable)
+	return table
+}
+
+// ZechLog returns the Zech logarithm z of i, defined by 1+g^i == g^z where
+// g is the generator of the field f. Combined with Log and Exp, it allows
+// adding elements represented by their logarithms: g^a+g^b == g^(a+z) where
+// z is the Zech logarithm of b-a. ZechLog returns false if 1+g^i == 0, which
+// happens exactly when i is a multiple of 255, or 2^m-1 in GF(2^m).
+func (f *Field) ZechLog(i int) (int, bool) {
+	z := f.zechTable[f.reduceExponent(i)]
+	return z, z >= 0
+}
+
+// Inv returns the multiplicative inverse of x, or an error if x==0 or x is
+// not an element of f.
 func (f *Field) Inv(x Num) (Num, error) {
 	if x == f.Zero() {
-		return f.Zero(), fmt.Errorf("Taking inverse of zero.")
+		return f.Zero(), ErrInvZero
+	}
+	logX, err := f.Log(x)
+	if err != nil {
+		return f.Zero(), err
 	}
-	logX, _ := f.Log(x)
 	return f.Exp(-logX), nil
 }
 
+// InvSlice returns the multiplicative inverses of all elements of xs, or an
+// error if any element is zero or not in the field. It computes a single
+// inverse and otherwise only multiplies, which is faster than calling Inv for
+// every element.
+func (f *Field) InvSlice(xs []Num) ([]Num, error) {
+	// The code below implements Montgomery's trick: after the first loop,
+	// prefix[i] holds xs[0]×…×xs[i-1]. Inverting the full product and then
+	// walking backwards peels off one factor at a time.
+	prefix := make([]Num, len(xs))
+	product := f.One()
+	for i, x := range xs {
+		if x == f.Zero() {
+			return nil, fmt.Errorf("%w: element %d", ErrInvZero, i)
+		}
+		if !f.inRange(x) {
+			return nil, fmt.Errorf("%w: element %d is %v", ErrOutOfRange, i, x)
+		}
+		prefix[i] = product
+		product = f.Mul(product, x)
+	}
+	inv, _ := f.Inv(product)
+	inverses := make([]Num, len(xs))
+	for i := len(xs) - 1; i >= 0; i-- {
+		// Here, inv == 1/(xs[0]×…×xs[i]).
+		inverses[i] = f.Mul(inv, prefix[i])
+		inv = f.Mul(inv, xs[i])
+	}
+	return inverses, nil
+}
+
 // Add(x, y) returns the sum of x and y in the field f.
 func (f *Field) Add(x, y Num) Num {
 	return x ^ y
 }
 
-// Mul returns the product of x and y in the field f.
+// Sub returns the difference x-y in the field f. Since the field has
+// characteristic two, subtraction is identical to addition.
+func (f *Field) Sub(x, y Num) Num {
+	return x ^ y
+}
+
+// Neg returns the additive inverse -x in the field f, which is x itself
+// since the field has characteristic two. Hence f.Add(x, f.Neg(y)) equals
+// f.Sub(x, y).
+func (f *Field) Neg(x Num) Num {
+	return x
+}
+
+// Mul returns the product of x and y in the field f. Both x and y must be
+// elements of f; Mul panics otherwise.
 func (f *Field) Mul(x, y Num) Num {
+	if !f.inRange(x) || !f.inRange(y) {
+		panic(fmt.Sprintf("Mul: %v × %v: %v.", x, y, ErrOutOfRange))
+	}
+	if table := f.mulTable.Load(); table != nil {
+		return table[x][y]
+	}
 	if x == f.Zero() || y == f.Zero() {
 		return f.Zero()
 	}
@@ -110,11 +306,404 @@ func (f *Field) Mul(x, y Num) Num {
 	return f.Exp(logX + logY)
 }
 
+// Sum returns the sum of all arguments in the field f, or zero if no
+// arguments are given.
+func (f *Field) Sum(xs ...Num) Num {
+	sum := f.Zero()
+	for _, x := range xs {
+		sum = f.Add(sum, x)
+	}
+	return sum
+}
+
+// Product returns the product of all arguments in the field f, or one if no
+// arguments are given.
+func (f *Field) Product(xs ...Num) Num {
+	product := f.One()
+	for _, x := range xs {
+		if x == f.Zero() {
+			return f.Zero()
+		}
+		product = f.Mul(product, x)
+	}
+	return product
+}
+
+// EnableMulTable makes subsequent calls to Mul use a full 256×256
+// multiplication table instead of log and exp lookups. The 64 KB table is
+// built on the first call; later calls do nothing. EnableMulTable is safe to
+// call concurrently with other methods on f. It has no effect for fields
+// with more than 256 elements.
+func (f *Field) EnableMulTable() {
+	if f.m > 8 {
+		return
+	}
+	f.mulOnce.Do(func() {
+		table := new([256][256]Num)
+		for x := 1; x < len(f.logTable); x++ {
+			for y := 1; y < len(f.logTable); y++ {
+				table[x][y] = f.Exp(f.logTable[x] + f.logTable[y])
+			}
+		}
+		f.mulTable.Store(table)
+	})
+}
+
+// MulSlice computes out[i] = out[i] + c×in[i] for every i. It panics if in
+// and out have different lengths, or if c or an entry of in is not an
+// element of f.
+func (f *Field) MulSlice(c Num, in []Num, out []Num) {
+	if len(in) != len(out) {
+		panic(fmt.Sprintf("MulSlice: length mismatch: %d != %d.", len(in), len(out)))
+	}
+	f.checkRange("MulSlice", c)
+	table := make([]Num, len(f.logTable))
+	for x := range table {
+		table[x] = f.Mul(c, Num(x))
+	}
+	for i, x := range in {
+		f.checkRange("MulSlice", x)
+		out[i] = out[i] ^ table[x]
+	}
+}
+
+// Dot returns the dot product Σ a[i]×b[i] of a and b in the field f, or an
+// error if a and b have different lengths or contain numbers that are not in
+// the field. Dot uses the multiplication table enabled by EnableMulTable, if
+// any.
+func (f *Field) Dot(a, b []Num) (Num, error) {
+	if len(a) != len(b) {
+		return f.Zero(), fmt.Errorf("Dot: length mismatch: %d != %d.", len(a), len(b))
+	}
+	sum := f.Zero()
+	for i := range a {
+		if !f.inRange(a[i]) || !f.inRange(b[i]) {
+			return f.Zero(), fmt.Errorf("Dot: %w: element %d is %v × %v", ErrOutOfRange, i, a[i], b[i])
+		}
+		sum = f.Add(sum, f.Mul(a[i], b[i]))
+	}
+	return sum, nil
+}
+
+// MulConstantTime returns the product of x and y in the field f without
+// input-dependent branches or table lookups. It is slower than Mul but
+// resistant to timing side channels, which makes it suitable for secret data.
+// Both x and y must be elements of f; since validating them would introduce a
+// branch, MulConstantTime does not check this and the result for
+// out-of-range inputs is unspecified.
+func (f *Field) MulConstantTime(x, y Num) Num {
+	product := Num(0)
+	poly := Num(f.poly)
+	for i := uint(0); i < f.m; i++ {
+		// -(y & 0x01) is all ones if the low bit of y is set; otherwise zero.
+		product = product ^ (x & -(y & 0x01))
+		carry := (x >> (f.m - 1)) & 0x01
+		x = (x << 1) ^ (poly & -carry)
+		y = y >> 1
+	}
+	return product
+}
+
+// MulMatrix returns the 8×8 matrix over GF(2) of the linear map x → c×x on
+// GF[2⁸], viewed as a vector of bits. Bit j of row i is bit i of c×2^j, so
+// bit i of c×x is the parity of row i AND x. MulMatrix panics unless f has
+// 256 elements, since the rows must fit in bytes.
+func (f *Field) MulMatrix(c Num) [8]uint8 {
+	if f.m != 8 {
+		panic(fmt.Sprintf("MulMatrix: GF(2^%d) elements are not bytes.", f.m))
+	}
+	var matrix [8]uint8
+	for j := uint(0); j < 8; j++ {
+		column := f.Mul(c, 1<<j)
+		for i := uint(0); i < 8; i++ {
+			matrix[i] |= uint8((column>>i)&0x01) << j
+		}
+	}
+	return matrix
+}
+
+// Div returns the quotient x/y in the field f, or an error if y==0 or if x
+// or y is not an element of f.
+func (f *Field) Div(x, y Num) (Num, error) {
+	if y == f.Zero() {
+		return f.Zero(), ErrDivideByZero
+	}
+	if !f.inRange(x) || !f.inRange(y) {
+		return f.Zero(), fmt.Errorf("%w: %v / %v", ErrOutOfRange, x, y)
+	}
+	if x == f.Zero() {
+		return f.Zero(), nil
+	}
+	logX, _ := f.Log(x)
+	logY, _ := f.Log(y)
+	return f.Exp(logX - logY), nil
+}
+
+// Square returns x×x in the field f. It panics if x is not an element of f.
+func (f *Field) Square(x Num) Num {
+	f.checkRange("Square", x)
+	return f.squareTable[x]
+}
+
+// Sqrt returns the unique y such that y×y == x in the field f. Squaring
+// is a bijection on GF[2⁸] since x^256 == x, so y == x^128; in general,
+// y == x^(2^(m-1)) in GF(2^m). Sqrt panics if x is not an element of f.
+func (f *Field) Sqrt(x Num) Num {
+	f.checkRange("Sqrt", x)
+	return f.Pow(x, 1<<(f.m-1))
+}
+
+// Frobenius returns the image of x under the Frobenius automorphism x → x².
+// It panics if x is not an element of f.
+func (f *Field) Frobenius(x Num) Num {
+	f.checkRange("Frobenius", x)
+	return f.Square(x)
+}
+
+// Conjugates returns the distinct conjugates x, x², x⁴, … of x, in the order
+// produced by repeatedly applying the Frobenius automorphism. It panics if x
+// is not an element of f.
+func (f *Field) Conjugates(x Num) []Num {
+	f.checkRange("Conjugates", x)
+	conjugates := []Num{x}
+	for y := f.Frobenius(x); y != x; y = f.Frobenius(y) {
+		conjugates = append(conjugates, y)
+	}
+	return conjugates
+}
+
+// CyclotomicCosets returns the cyclotomic cosets of 2 modulo 255: the
+// partition of {0, …, 254} into sets {s, 2s, 4s, …} modulo 255. Each coset
+// is listed in that order, and the cosets are ordered by their smallest
+// element s. For any generator g of GF[2⁸], the powers g^i for i in a coset
+// are the conjugates of g^s; see Conjugates.
+func CyclotomicCosets() [][]int {
+	const n = 255
+	var cosets [][]int
+	seen := make([]bool, n)
+	for s := 0; s < n; s++ {
+		if seen[s] {
+			continue
+		}
+		var coset []int
+		for i := s; !seen[i]; i = 2 * i % n {
+			seen[i] = true
+			coset = append(coset, i)
+		}
+		cosets = append(cosets, coset)
+	}
+	return cosets
+}
+
+// Trace returns the absolute trace x + x² + x⁴ + … + x^(2^(m-1)) of x, which
+// is always zero or one. It panics if x is not an element of f.
+func (f *Field) Trace(x Num) Num {
+	f.checkRange("Trace", x)
+	trace := x
+	for i := uint(1); i < f.m; i++ {
+		x = f.Square(x)
+		trace = f.Add(trace, x)
+	}
+	return trace
+}
+
+// SolveQuadratic returns the distinct roots of a×x² + b×x + c in increasing
+// order. There are zero, one, or two roots. If a is zero the equation is
+// solved as a linear equation; it is an error if both a and b are zero or
+// if a, b, or c is not an element of f.
+func (f *Field) SolveQuadratic(a, b, c Num) ([]Num, error) {
+	if !f.inRange(a) || !f.inRange(b) || !f.inRange(c) {
+		return nil, fmt.Errorf("%w: SolveQuadratic(%v, %v, %v)", ErrOutOfRange, a, b, c)
+	}
+	switch {
+	case a == f.Zero() && b == f.Zero():
+		return nil, fmt.Errorf("SolveQuadratic(%v, %v, %v): degenerate equation.", a, b, c)
+	case a == f.Zero():
+		x, _ := f.Div(c, b)
+		return []Num{x}, nil
+	case b == f.Zero():
+		// Squaring is a bijection, so x² == c/a has exactly one root.
+		x, _ := f.Div(c, a)
+		return []Num{f.Sqrt(x)}, nil
+	}
+	// Substituting x == (b/a)×y gives y² + y == k where k == a×c/b². This
+	// has a solution if and only if Tr(k) == 0, in which case the
+	// solutions are y and y+1.
+	scale, _ := f.Div(b, a)
+	k, _ := f.Div(f.Mul(a, c), f.Square(b))
+	if f.Trace(k) != f.Zero() {
+		return nil, nil
+	}
+	y := f.halfTrace(k)
+	x0, x1 := f.Mul(scale, y), f.Mul(scale, f.Add(y, f.One()))
+	if x1 < x0 {
+		x0, x1 = x1, x0
+	}
+	return []Num{x0, x1}, nil
+}
+
+// halfTrace returns a solution y of y² + y == k, where k has trace zero.
+func (f *Field) halfTrace(k Num) Num {
+	// The code below uses an element d of trace one and computes
+	// y == Σ_{i=0}^{m-2} (Σ_{j=i+1}^{m-1} d^(2^j)) k^(2^i); see Lidl and
+	// Niederreiter, Finite Fields, section 3.4.
+	d := f.One()
+	for f.Trace(d) == f.Zero() {
+		d++
+	}
+	d2 := make([]Num, f.m) // d2[j] == d^(2^j).
+	d2[0] = d
+	for j := uint(1); j < f.m; j++ {
+		d2[j] = f.Square(d2[j-1])
+	}
+	y := f.Zero()
+	k2 := k // k2 == k^(2^i).
+	for i := uint(0); i+1 < f.m; i++ {
+		inner := f.Zero()
+		for j := i + 1; j < f.m; j++ {
+			inner = f.Add(inner, d2[j])
+		}
+		y = f.Add(y, f.Mul(inner, k2))
+		k2 = f.Square(k2)
+	}
+	return y
+}
+
+// OrderOf returns the multiplicative order of x, the smallest positive k
+// such that x^k == 1, or an error if x==0 or x is not an element of f.
+func (f *Field) OrderOf(x Num) (int, error) {
+	if x == f.Zero() {
+		return 0, fmt.Errorf("Zero has no multiplicative order.")
+	}
+	logX, err := f.Log(x)
+	if err != nil {
+		return 0, err
+	}
+	return len(f.expTable) / gcd(len(f.expTable), logX), nil
+}
+
+// IsPrimitive returns true if x generates the multiplicative group of the
+// field f, i.e. if x has order 255, or 2^m-1 in GF(2^m).
+func (f *Field) IsPrimitive(x Num) bool {
+	order, err := f.OrderOf(x)
+	return err == nil && order == len(f.expTable)
+}
+
+// Elements returns all elements of the field f in ascending order.
+func (f *Field) Elements() []Num {
+	elements := make([]Num, len(f.logTable))
+	for i := range elements {
+		elements[i] = Num(i)
+	}
+	return elements
+}
+
+// PowersOfGenerator returns the powers g^0, g^1, …, g^254 of the generator g
+// of the field f, or up to g^(2^m-2) in GF(2^m). Together they are all the
+// non-zero elements, each appearing once. The result is the same as ExpTable.
+func (f *Field) PowersOfGenerator() []Num {
+	return f.ExpTable()
+}
+
+// Generators returns all primitive elements of the field f in ascending order.
+func (f *Field) Generators() []Num {
+	var generators []Num
+	for n := 1; n < len(f.logTable); n++ {
+		if f.IsPrimitive(Num(n)) {
+			generators = append(generators, Num(n))
+		}
+	}
+	return generators
+}
+
+// Pow returns x raised to the power n in the field f. Negative exponents
+// are computed via the multiplicative inverse of x. For x==0, Pow returns
+// one when n==0 and zero otherwise, since zero has no inverse. Pow panics if
+// x is not an element of f.
+func (f *Field) Pow(x Num, n int) Num {
+	f.checkRange("Pow", x)
+	if n == 0 {
+		return f.One()
+	}
+	if x == f.Zero() {
+		return f.Zero()
+	}
+	logX, _ := f.Log(x)
+	// Reduce n first so that the product cannot overflow.
+	return f.Exp(logX * f.reduceExponent(n))
+}
+
 // String returns a readable string representation of the number n in GF[2⁸].
 func (n Num) String() string {
 	return fmt.Sprintf("%b", uint(n))
 }
 
+// Format implements fmt.Formatter. The verbs %v and %s write the binary
+// representation returned by String, while %b, %o, %d, %x, %X and other
+// integer verbs format n as an unsigned integer. Flags, width and precision
+// are honored, and %#v writes n in Go syntax.
+func (n Num) Format(s fmt.State, verb rune) {
+	format := "%"
+	for _, flag := range "+-# 0" {
+		if s.Flag(int(flag)) {
+			format += string(flag)
+		}
+	}
+	if width, ok := s.Width(); ok {
+		format += fmt.Sprint(width)
+	}
+	if precision, ok := s.Precision(); ok {
+		format += "." + fmt.Sprint(precision)
+	}
+	switch {
+	case verb == 'v' && s.Flag('#'):
+		fmt.Fprintf(s, format+"v", uint(n))
+	case verb == 'v' || verb == 's':
+		fmt.Fprintf(s, format+"s", n.String())
+	default:
+		fmt.Fprintf(s, format+string(verb), uint(n))
+	}
+}
+
+// Valid returns true if n is an element of GF[2⁸], i.e. if n<256. Fields
+// with more elements accept larger values.
+func (n Num) Valid() bool {
+	return n <= 0xff
+}
+
+// Byte returns n as a byte, or an error if n does not fit in a byte. Every
+// element of GF[2⁸] fits in a byte.
+func (n Num) Byte() (byte, error) {
+	if !n.Valid() {
+		return 0, fmt.Errorf("%v does not fit in a byte.", n)
+	}
+	return byte(n), nil
+}
+
+// NumFromByte returns the element of GF[2⁸] represented by b.
+func NumFromByte(b byte) Num {
+	return Num(b)
+}
+
+// ParseNum parses the binary string representation of a number in GF[2⁸],
+// as returned by String, optionally with leading zeros.
+func ParseNum(s string) (Num, error) {
+	if s == "" {
+		return 0, fmt.Errorf("Cannot parse empty string as a number.")
+	}
+	n := Num(0)
+	for _, c := range s {
+		if c != '0' && c != '1' {
+			return 0, fmt.Errorf("Cannot parse %q as a number: invalid digit %q.", s, c)
+		}
+		n = n<<1 | Num(c-'0')
+		if n > 0xff {
+			return 0, fmt.Errorf("Cannot parse %q as a number: out of range.", s)
+		}
+	}
+	return n, nil
+}
+
 // String returns a readable string representation of the irreducible
 // polynomial p.
 func (p Irreducible) String() string {
@@ -124,26 +713,39 @@ func (p Irreducible) String() string {
 // NewField creates a new version of GF[2⁸] using the supplied
 // irreducible polynomial and generator.
 func NewField(polynomial Irreducible, generator Num) (*Field, error) {
-	if polynomial|0x1FF != 0x1FF {
-		return nil, fmt.Errorf("%v has too high degree.", polynomial)
-	}
-	if polynomial&0x100 == 0 {
-		return nil, fmt.Errorf("%v has too low degree.", polynomial)
+	return NewFieldGF2m(8, uint(polynomial), uint(generator))
+}
+
+// NewFieldFromGenerator creates a new version of GF[2⁸] with the supplied
+// generator, using the first polynomial, in the order of
+// IrreduciblePolynomials, for which generator generates the field. It
+// returns an error if there is no such polynomial.
+func NewFieldFromGenerator(generator Num) (*Field, error) {
+	for _, p := range IrreduciblePolynomials() {
+		if f, err := NewField(p, generator); err == nil {
+			return f, nil
+		}
 	}
-	if generator == 0 || generator == 1 {
-		return nil, fmt.Errorf("%v is not a generator.", generator)
+	return nil, fmt.Errorf("%v is not a generator for any irreducible polynomial.", generator)
+}
+
+// NewFieldGF2m creates a new version of GF(2^m), for 2 ≤ m ≤ 16, using the
+// supplied irreducible polynomial of degree m and generator.
+func NewFieldGF2m(m uint, polynomial, generator uint) (*Field, error) {
+	if m < 2 || m > 16 {
+		return nil, fmt.Errorf("GF(2^%d) is not supported.", m)
 	}
-	f := &Field{
-		poly: polynomial,
-		g:    generator,
+	if err := checkParameters(m, Irreducible(polynomial), Num(generator)); err != nil {
+		return nil, err
 	}
+	f := newField(m, Irreducible(polynomial), Num(generator))
 	// Build expTable and logTable.
-	for n := 0; n < 256; n++ {
+	for n := range f.logTable {
 		// Fill with zeroes to have know values everywhere.
 		f.logTable[n] = 0
 	}
 	product := Num(0x01) // The number 1.
-	for i := 0; i < 255; i++ {
+	for i := range f.expTable {
 		if i != 0 && product == 1 {
 			return nil, fmt.Errorf("%v is not a generator.", f.g)
 		}
@@ -151,17 +753,129 @@ func NewField(polynomial Irreducible, generator Num) (*Field, error) {
 		f.logTable[product] = i
 		product = multiply(product, f.g, f.poly)
 	}
-	// Double-check that the generator has generated all of GF[2⁸]
+	// Double-check that the generator has generated all of GF(2^m)
 	// by checking that every number other then zero and one has
 	// non-zero logarithm.
-	for n := 2; n < 256; n++ {
+	for n := 2; n < len(f.logTable); n++ {
 		if f.logTable[n] == 0 {
 			return nil, fmt.Errorf("%v is not a generator.", f.g)
 		}
 	}
+	f.buildSquareTable()
+	f.buildZechTable()
 	return f, nil
 }
 
+// newField returns a Field for GF(2^m) with allocated but empty tables.
+func newField(m uint, polynomial Irreducible, generator Num) *Field {
+	return &Field{
+		m:           m,
+		poly:        polynomial,
+		g:           generator,
+		expTable:    make([]Num, 1<<m-1),
+		logTable:    make([]int, 1<<m),
+		squareTable: make([]Num, 1<<m),
+		zechTable:   make([]int, 1<<m-1),
+	}
+}
+
+// NewQRField returns GF[2⁸] defined by x⁸+x⁴+x³+x²+1 with generator x, as
+// used by QR codes.
+func NewQRField() *Field {
+	return mustNewField(0x11d, 0x02)
+}
+
+var (
+	standardField     *Field
+	standardFieldOnce sync.Once
+)
+
+// StandardField returns a shared instance of the field returned by
+// NewQRField. The field is built on the first call and the same pointer is
+// returned on every call, so callers must not modify it, for example with
+// UnmarshalBinary.
+func StandardField() *Field {
+	standardFieldOnce.Do(func() {
+		standardField = NewQRField()
+	})
+	return standardField
+}
+
+// NewAESField returns GF[2⁸] defined by the Rijndael polynomial
+// x⁸+x⁴+x³+x+1 with generator x+1, as used by AES.
+func NewAESField() *Field {
+	return mustNewField(0x11b, 0x03)
+}
+
+// mustNewField is like NewField but panics if the parameters are invalid.
+func mustNewField(polynomial Irreducible, generator Num) *Field {
+	f, err := NewField(polynomial, generator)
+	if err != nil {
+		panic(fmt.Sprintf("gf256: invalid field parameters: %v", err))
+	}
+	return f
+}
+
+// checkParameters returns an error if polynomial is not an irreducible
+// polynomial of degree m or if generator is trivially not a generator.
+func checkParameters(m uint, polynomial Irreducible, generator Num) error {
+	if polynomial>>(m+1) != 0 {
+		return fmt.Errorf("%v has too high degree.", polynomial)
+	}
+	if polynomial&(1<<m) == 0 {
+		return fmt.Errorf("%v has too low degree.", polynomial)
+	}
+	if hasFactor(uint(polynomial), m) {
+		return fmt.Errorf("%v is reducible.", polynomial)
+	}
+	if generator == 0 || generator == 1 || generator >= 1<<m {
+		return fmt.Errorf("%v is not a generator.", generator)
+	}
+	return nil
+}
+
+// reduceExponent returns x modulo the order n of the multiplicative group
+// of f, in the range 0 to n-1. In Go, x%n has the sign of x, so negative
+// remainders are shifted up by n; for example, -1 becomes n-1 and -n
+// becomes 0.
+func (f *Field) reduceExponent(x int) int {
+	n := len(f.expTable)
+	x = x % n
+	if x < 0 {
+		x = x + n
+	}
+	return x
+}
+
+// inRange returns true if x is an element of the field f.
+func (f *Field) inRange(x Num) bool {
+	return x < Num(len(f.logTable))
+}
+
+// checkRange panics with a message like that of Mul if x is not an element
+// of the field f. The message starts with name, the calling function.
+func (f *Field) checkRange(name string, x Num) {
+	if !f.inRange(x) {
+		panic(fmt.Sprintf("%s: %v: %v.", name, x, ErrOutOfRange))
+	}
+}
+
+// buildZechTable builds zechTable from expTable and logTable.
+func (f *Field) buildZechTable() {
+	f.zechTable[0] = -1
+	for i := 1; i < len(f.expTable); i++ {
+		f.zechTable[i] = f.logTable[f.Add(f.One(), f.expTable[i])]
+	}
+}
+
+// buildSquareTable builds squareTable from expTable and logTable.
+func (f *Field) buildSquareTable() {
+	f.squareTable[0] = f.Zero()
+	for n := 1; n < len(f.logTable); n++ {
+		f.squareTable[n] = f.Exp(2 * f.logTable[n])
+	}
+}
+
 func multiply(x, y Num, poly Irreducible) Num {
 	// Repeated squaring; optimize for small y.
 	product := Num(0)
@@ -175,27 +889,47 @@ func multiply(x, y Num, poly Irreducible) Num {
 	// Reduce modulo the irreducible polynomial.
 	// Casting poly to Num is fine since both Num
 	// and Irreducible are represented as uint.
-	poly_msb := msb(uint(poly))
-	for product >= 256 {
-		product_msb := msb(uint(product))
-		product = product ^ (Num(poly) << (product_msb - poly_msb))
+	poly_len := bits.Len(uint(poly))
+	for product_len := bits.Len(uint(product)); product_len >= poly_len; product_len = bits.Len(uint(product)) {
+		product = product ^ (Num(poly) << uint(product_len-poly_len))
 	}
 	return product
 }
 
+// modInverse returns the inverse of a modulo n, where gcd(a, n) must be one.
+func modInverse(a, n int) int {
+	// The code below implements the extended Euclidean algorithm, keeping
+	// only the coefficients of a.
+	t, newT := 0, 1
+	r, newR := n, a%n
+	for newR != 0 {
+		q := r / newR
+		t, newT = newT, t-q*newT
+		r, newR = newR, r-q*newR
+	}
+	if t < 0 {
+		t = t + n
+	}
+	return t
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// msb returns the position of the most significant set bit in n, or zero
+// if n==0.
 func msb(n uint) uint {
-	i := uint(0)
-	for {
-		n = n >> 1
-		if n == 0 {
-			return i
-		}
-		i = i + 1
+	if n == 0 {
+		return 0
 	}
+	return uint(bits.Len(n) - 1)
 }
 
 func bitmaskToString(n uint) string {
-	terms := []string{"1", "x", "x²", "x³", "x⁴", "x⁵", "x⁶", "x⁷", "x⁸"}
 	if n == 0 {
 		return "0"
 	}
@@ -204,8 +938,13 @@ func bitmaskToString(n uint) string {
 	for n != 0 {
 		if n&0x01 != 0 {
 			nextTerm := fmt.Sprintf("x^%d", i)
-			if i < 9 {
-				nextTerm = terms[i]
+			switch {
+			case i == 0:
+				nextTerm = "1"
+			case i == 1:
+				nextTerm = "x"
+			case i < 9:
+				nextTerm = "x" + superscript(i)
 			}
 			if s != "" {
 				s = nextTerm + "+" + s
@@ -218,3 +957,16 @@ func bitmaskToString(n uint) string {
 	}
 	return s
 }
+
+// superscript writes the non-negative integer n with superscript digits.
+func superscript(n int) string {
+	digits := []rune("⁰¹²³⁴⁵⁶⁷⁸⁹")
+	s := ""
+	for {
+		s = string(digits[n%10]) + s
+		n = n / 10
+		if n == 0 {
+			return s
+		}
+	}
+}
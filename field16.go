@@ -0,0 +1,73 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gf256
+
+import (
+	"fmt"
+)
+
+// Field16 represents an instantiation of GF(2¹⁶) with elements stored as
+// 16-bit symbols, which is useful for Reed-Solomon codes longer than 255
+// symbols. It is backed by a Field built with NewFieldGF2m.
+type Field16 struct {
+	f *Field
+}
+
+// NewField16 creates a new version of GF(2¹⁶) using the supplied
+// irreducible polynomial of degree 16 and generator. A commonly used
+// polynomial is x¹⁶+x¹²+x³+x+1, or 0x1100b, with generator x.
+func NewField16(polynomial, generator uint) (*Field16, error) {
+	if polynomial>>16 != 1 {
+		return nil, fmt.Errorf("%v does not have degree 16.", Irreducible(polynomial))
+	}
+	f, err := NewFieldGF2m(16, polynomial, generator)
+	if err != nil {
+		return nil, err
+	}
+	return &Field16{f}, nil
+}
+
+// Field returns the underlying Field, which can be used for polynomial
+// arithmetic over GF(2¹⁶).
+func (f *Field16) Field() *Field {
+	return f.f
+}
+
+// Add returns x+y.
+func (f *Field16) Add(x, y uint16) uint16 {
+	return x ^ y
+}
+
+// Mul returns x×y.
+func (f *Field16) Mul(x, y uint16) uint16 {
+	return uint16(f.f.Mul(Num(x), Num(y)))
+}
+
+// Inv returns the multiplicative inverse of x, or an error if x is zero.
+func (f *Field16) Inv(x uint16) (uint16, error) {
+	inv, err := f.f.Inv(Num(x))
+	return uint16(inv), err
+}
+
+// Log returns the logarithm of x with respect to the generator, or an
+// error if x is zero.
+func (f *Field16) Log(x uint16) (int, error) {
+	return f.f.Log(Num(x))
+}
+
+// Exp returns the generator raised to the power x.
+func (f *Field16) Exp(x int) uint16 {
+	return uint16(f.f.Exp(x))
+}
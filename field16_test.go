@@ -0,0 +1,92 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gf256
+
+import (
+	"testing"
+)
+
+func TestNewField16(t *testing.T) {
+	if _, err := NewField16(0x1100b, 0x02); err != nil {
+		t.Errorf("Could not create GF(2¹⁶) with 0x1100b: %v.", err)
+	}
+	for _, p := range []uint{0x11d, 0x2100b, 0x10000} {
+		if _, err := NewField16(p, 0x02); err == nil {
+			t.Errorf("NewField16(%#x, 0x02): expected error, got nil.", p)
+		}
+	}
+}
+
+func TestField16ArithmeticOperators(t *testing.T) {
+	f, err := NewField16(0x1100b, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF(2¹⁶): %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	// The field is too large to test every pair, so step through it with
+	// strides coprime to 2¹⁶.
+	for i := uint(0); i < 1<<16; i += 7 {
+		x := uint16(i)
+		if y := f.Add(x, 0); x != y {
+			t.Errorf("Error adding with zero: expected %v, got %v.", x, y)
+		}
+		if y := f.Mul(x, 0); y != 0 {
+			t.Errorf("Error multiplying by zero: expected 0, got %v.", y)
+		}
+		if y := f.Mul(x, 1); x != y {
+			t.Errorf("Error multiplying by one: expected %v, got %v.", x, y)
+		}
+		if x != 0 {
+			inv, err := f.Inv(x)
+			if err != nil {
+				t.Errorf("Error computing inverse of %v: %v", x, err)
+			}
+			if y := f.Mul(x, inv); y != 1 {
+				t.Errorf("Error multiplying %v by its inverse: expected 1, got %v.", x, y)
+			}
+		}
+	}
+	for i := uint(1); i < 1<<16; i += 251 {
+		for j := uint(1); j < 1<<16; j += 509 {
+			x := uint16(i)
+			y := uint16(j)
+			logX, errX := f.Log(x)
+			logY, errY := f.Log(y)
+			if errX != nil {
+				t.Errorf("Error computing logarithm of %v: %v", x, errX)
+			}
+			if errY != nil {
+				t.Errorf("Error computing logarithm of %v: %v", y, errY)
+			}
+			expected := f.Exp(logX + logY)
+			actual := f.Mul(x, y)
+			if expected != actual {
+				t.Errorf("%v × %v: expected %v, got %v.", x, y, expected, actual)
+			}
+			if expected := uint16(multiply(Num(x), Num(y), 0x1100b)); expected != actual {
+				t.Errorf("%v × %v: expected %v from direct multiplication, got %v.", x, y, expected, actual)
+			}
+		}
+	}
+	if _, err := f.Inv(0); err == nil {
+		t.Errorf("Inv(0): expected error, got nil.")
+	}
+	if _, err := f.Log(0); err == nil {
+		t.Errorf("Log(0): expected error, got nil.")
+	}
+	if f.Field().Cardinality() != 1<<16 {
+		t.Errorf("Field().Cardinality(): expected %d, got %d.", 1<<16, f.Field().Cardinality())
+	}
+}
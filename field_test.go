@@ -14,7 +14,10 @@
 
 package gf256
 
+import "errors"
 import "fmt"
+import "math/bits"
+import "sync"
 import "testing"
 
 func ExampleNum() {
@@ -23,6 +26,31 @@ func ExampleNum() {
 	// Output: 10111
 }
 
+func ExampleNum_Format() {
+	n := Num(0x17)
+	fmt.Printf("%v %b %d %x %X %#x\n", n, n, n, n, n, n)
+	fmt.Printf("[%8v] [%-8v] [%08b] [%4d] [%.2x]\n", n, n, n, n, n)
+	// Output:
+	// 10111 10111 23 17 17 0x17
+	// [   10111] [10111   ] [00010111] [  23] [17]
+}
+
+func ExampleParseNum() {
+	n, _ := ParseNum("00010111")
+	fmt.Println(uint(n))
+	// Output: 23
+}
+
+func ExampleNum_Byte() {
+	b, _ := Num(0x17).Byte()
+	_, err := Num(0x100).Byte()
+	fmt.Println(b, NumFromByte(b))
+	fmt.Println(err)
+	// Output:
+	// 23 10111
+	// 100000000 does not fit in a byte.
+}
+
 func ExampleField() {
 	f, _ := NewField(0x11d, 0x2)
 	fmt.Println(f.Polynomial())
@@ -36,6 +64,12 @@ func ExampleField() {
 	// 1
 }
 
+func ExampleNewAESField() {
+	f := NewAESField()
+	fmt.Printf("%x\n", uint(f.Mul(0x57, 0x83)))
+	// Output: c1
+}
+
 func ExampleField_Exp() {
 	f, _ := NewField(0x11d, 0x2)
 	fmt.Println(f.Exp(0))
@@ -62,6 +96,33 @@ func ExampleField_Log() {
 	// 51
 }
 
+func ExampleField_LogBase() {
+	f, _ := NewField(0x11d, 0x2)
+	log, _ := f.LogBase(0x04, 0x02)
+	x, _ := f.ExpBase(0x04, log)
+	fmt.Println(log, x)
+	// Output:
+	// 128 10
+}
+
+func ExampleField_ExpTable() {
+	f, _ := NewField(0x11d, 0x2)
+	table := f.ExpTable()
+	fmt.Println(len(table), table[0], table[1], table[8])
+	// Output:
+	// 255 1 10 11101
+}
+
+func ExampleField_ZechLog() {
+	f, _ := NewField(0x11d, 0x2)
+	// Add x^3 and x^10 without leaving the log domain: x^3 + x^10 ==
+	// x^3 × (1 + x^7) == x^(3+z) where z is the Zech logarithm of 7.
+	z, _ := f.ZechLog(7)
+	fmt.Println(f.Exp(3+z), f.Add(f.Exp(3), f.Exp(10)))
+	// Output:
+	// 1111100 1111100
+}
+
 func ExampleField_Inv() {
 	f, _ := NewField(0x11d, 0x2)
 	n, _ := f.Inv(Num(0x0a))
@@ -70,6 +131,14 @@ func ExampleField_Inv() {
 	// 11011101
 }
 
+func ExampleField_InvSlice() {
+	f, _ := NewField(0x11d, 0x2)
+	inverses, _ := f.InvSlice([]Num{0x01, 0x02, 0x0a})
+	fmt.Println(inverses)
+	// Output:
+	// [1 10001110 11011101]
+}
+
 func ExampleField_Add() {
 	f, _ := NewField(0x11d, 0x2)
 	x, y := Num(0x0a), Num(0x1f)
@@ -78,6 +147,14 @@ func ExampleField_Add() {
 	// 1010 11111 10101
 }
 
+func ExampleField_Sub() {
+	f, _ := NewField(0x11d, 0x2)
+	x, y := Num(0x0a), Num(0x1f)
+	fmt.Println(x, y, f.Sub(x, y))
+	// Output:
+	// 1010 11111 10101
+}
+
 func ExampleField_Mul() {
 	f, _ := NewField(0x11d, 0x2)
 	x, y := Num(0x0a), Num(0x1f)
@@ -86,6 +163,122 @@ func ExampleField_Mul() {
 	// 1010 11111 11000110
 }
 
+func ExampleField_Sum() {
+	f, _ := NewField(0x11d, 0x2)
+	fmt.Println(f.Sum(0x0a, 0x1f, 0x01), f.Sum())
+	// Output:
+	// 10100 0
+}
+
+func ExampleField_Product() {
+	f, _ := NewField(0x11d, 0x2)
+	fmt.Println(f.Product(0x02, 0x04, 0x10), f.Product())
+	// Output:
+	// 10000000 1
+}
+
+func ExampleField_MulSlice() {
+	f, _ := NewField(0x11d, 0x2)
+	in := []Num{0x01, 0x02, 0x1f}
+	out := []Num{0x00, 0x01, 0x00}
+	f.MulSlice(Num(0x0a), in, out)
+	fmt.Println(out)
+	// Output:
+	// [1010 10101 11000110]
+}
+
+func ExampleField_Dot() {
+	f, _ := NewField(0x11d, 0x2)
+	dot, _ := f.Dot([]Num{0x01, 0x02, 0x03}, []Num{0x03, 0x02, 0x01})
+	fmt.Println(dot)
+	// Output:
+	// 100
+}
+
+func ExampleField_MulConstantTime() {
+	f, _ := NewField(0x11d, 0x2)
+	x, y := Num(0x0a), Num(0x1f)
+	fmt.Println(x, y, f.MulConstantTime(x, y))
+	// Output:
+	// 1010 11111 11000110
+}
+
+func ExampleField_Div() {
+	f, _ := NewField(0x11d, 0x2)
+	x, y := Num(0xc6), Num(0x1f)
+	q, _ := f.Div(x, y)
+	fmt.Println(x, y, q)
+	// Output:
+	// 11000110 11111 1010
+}
+
+func ExampleField_Square() {
+	f, _ := NewField(0x11d, 0x2)
+	fmt.Println(f.Square(Num(0x0a)))
+	// Output:
+	// 1000100
+}
+
+func ExampleField_Sqrt() {
+	f, _ := NewField(0x11d, 0x2)
+	fmt.Println(f.Sqrt(Num(0x44)))
+	// Output:
+	// 1010
+}
+
+func ExampleField_Conjugates() {
+	f, _ := NewField(0x11d, 0x2)
+	fmt.Println(f.Conjugates(Num(0x02)))
+	// Output:
+	// [10 100 10000 11101 1001100 10011101 1011111 10000101]
+}
+
+func ExampleField_OrderOf() {
+	f, _ := NewField(0x11d, 0x2)
+	for _, x := range []Num{0x01, 0x02, 0x04, 0x08, 0x1d} {
+		order, _ := f.OrderOf(x)
+		fmt.Println(x, order)
+	}
+	// Output:
+	// 1 1
+	// 10 255
+	// 100 255
+	// 1000 85
+	// 11101 255
+}
+
+func ExampleField_InSubfield() {
+	f, _ := NewField(0x11d, 0x2)
+	fmt.Println(f.Subfields())
+	fmt.Println(f.InSubfield(f.Exp(85), 4), f.InSubfield(f.Exp(85), 2))
+	// Output:
+	// [2 4 16 256]
+	// true false
+}
+
+func ExampleField_Pow() {
+	f, _ := NewField(0x11d, 0x2)
+	x := Num(0x0a)
+	fmt.Println(f.Pow(x, 2))
+	fmt.Println(f.Pow(x, -1))
+	fmt.Println(f.Pow(x, 1001))
+	// Output:
+	// 1000100
+	// 11011101
+	// 1010
+}
+
+func ExampleNewFieldGF2m() {
+	f, _ := NewFieldGF2m(4, 0x13, 0x2)
+	fmt.Println(f.Polynomial())
+	fmt.Println(f.Exp(4), f.Exp(15))
+	fmt.Println(f.Mul(0x0a, 0x0f))
+	// Output:
+	// x⁴+x+1
+	// 11 1
+	// 1100
+}
+
 func TestToString(t *testing.T) {
 	testData := []struct {
 		coefficients uint
@@ -162,7 +355,7 @@ func TestNewFieldWithReduciblePolynomial(t *testing.T) {
 	if err == nil {
 		t.Errorf("Expected error return value from NewField().")
 	}
-	if err.Error() != "10 is not a generator." {
+	if err.Error() != "x⁸+1 is reducible." {
 		t.Errorf("Unexpected error message: %v", err)
 	}
 }
@@ -201,6 +394,101 @@ func TestFieldAccessors(t *testing.T) {
 	}
 }
 
+func TestEqual(t *testing.T) {
+	f1, err1 := NewField(0x11d, 0x02)
+	f2, err2 := NewField(0x11d, 0x02)
+	f3, err3 := NewField(0x11d, 0x04)
+	f4, err4 := NewField(0x11b, 0x03)
+	if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+		t.Errorf("Could not create fields: %v, %v, %v, %v.", err1, err2, err3, err4)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	if !f1.Equal(f1) || !f1.Equal(f2) || !f2.Equal(f1) || !f1.Equal(NewQRField()) {
+		t.Errorf("Fields with the same parameters should be equal.")
+	}
+	if f1.Equal(f3) || f3.Equal(f1) {
+		t.Errorf("Fields with different generators should not be equal.")
+	}
+	if f1.Equal(f4) || f4.Equal(f1) || !f4.Equal(NewAESField()) {
+		t.Errorf("Fields with different polynomials should not be equal.")
+	}
+	if f1.Equal(nil) {
+		t.Errorf("A field should not equal nil.")
+	}
+	// Different generators give different log tables.
+	log1, _ := f1.Log(0x04)
+	log3, _ := f3.Log(0x04)
+	if log1 == log3 {
+		t.Errorf("Log(100) should differ between %v and %v.", f1.Generator(), f3.Generator())
+	}
+}
+
+func TestFieldStructure(t *testing.T) {
+	testData := []struct {
+		m                     uint
+		polynomial, generator uint
+		subfields             string
+	}{
+		{2, 0x7, 0x2, "[2 4]"},
+		{3, 0xb, 0x2, "[2 8]"},
+		{4, 0x13, 0x2, "[2 4 16]"},
+		{8, 0x11d, 0x2, "[2 4 16 256]"},
+		{12, 0x1053, 0x2, "[2 4 8 16 64 4096]"},
+	}
+	for _, data := range testData {
+		f, err := NewFieldGF2m(data.m, data.polynomial, data.generator)
+		if err != nil {
+			t.Errorf("Could not create GF(2^%d): %v.", data.m, err)
+			continue
+		}
+		if c := f.Characteristic(); c != 2 {
+			t.Errorf("GF(2^%d): unexpected characteristic %d.", data.m, c)
+		}
+		if c := f.Cardinality(); c != 1<<data.m {
+			t.Errorf("GF(2^%d): unexpected cardinality %d.", data.m, c)
+		}
+		if s := fmt.Sprint(f.Subfields()); s != data.subfields {
+			t.Errorf("GF(2^%d): expected subfields %s, got %s.", data.m, data.subfields, s)
+		}
+	}
+}
+
+func TestInSubfield(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	for _, order := range f.Subfields() {
+		if !f.InSubfield(f.Zero(), order) || !f.InSubfield(f.One(), order) {
+			t.Errorf("Zero and one should belong to the subfield of order %d.", order)
+		}
+		count := 0
+		for i := 0; i < 256; i++ {
+			if f.InSubfield(Num(i), order) {
+				count++
+			}
+		}
+		if count != order {
+			t.Errorf("Subfield of order %d: found %d elements.", order, count)
+		}
+	}
+	// The elements of GF(2⁴) are zero and the powers of g^17.
+	for i := 0; i < 15; i++ {
+		if x := f.Pow(f.Exp(17), i); !f.InSubfield(x, 16) {
+			t.Errorf("%v should belong to the subfield of order 16.", x)
+		}
+	}
+	if f.InSubfield(f.Generator(), 16) {
+		t.Errorf("%v should not belong to the subfield of order 16.", f.Generator())
+	}
+	for _, order := range []int{0, 1, 3, 8, 32, 255, 512} {
+		if f.InSubfield(f.One(), order) {
+			t.Errorf("There is no subfield of order %d.", order)
+		}
+	}
+}
+
 func TestArithmeticOperators(t *testing.T) {
 	f, err := NewField(0x11d, 0x02)
 	if err != nil {
@@ -273,6 +561,42 @@ func TestAddition(t *testing.T) {
 	}
 }
 
+func TestSub(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	for i := 0; i < 256; i++ {
+		for j := 0; j < 256; j++ {
+			x, y := Num(i), Num(j)
+			if f.Sub(x, y) != f.Add(x, y) {
+				t.Errorf("%v - %v: expected %v, actual %v.", x, y, f.Add(x, y), f.Sub(x, y))
+			}
+			if f.Add(f.Sub(x, y), y) != x {
+				t.Errorf("(%v - %v) + %v != %v.", x, y, y, x)
+			}
+		}
+	}
+}
+
+func TestNeg(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	for i := 0; i < 256; i++ {
+		x := Num(i)
+		if sum := f.Add(x, f.Neg(x)); sum != f.Zero() {
+			t.Errorf("%v + -%v: expected 0, actual %v.", x, x, sum)
+		}
+		if y := Num(255 - i); f.Add(y, f.Neg(x)) != f.Sub(y, x) {
+			t.Errorf("%v + -%v: expected %v, actual %v.", y, x, f.Sub(y, x), f.Add(y, f.Neg(x)))
+		}
+	}
+}
+
 func TestInverse(t *testing.T) {
 	f, err := NewField(0x11d, 0x02)
 	if err != nil {
@@ -300,6 +624,31 @@ func TestInverse(t *testing.T) {
 	}
 }
 
+func TestInvSlice(t *testing.T) {
+	for _, f := range []*Field{NewQRField(), NewAESField()} {
+		xs := make([]Num, 255)
+		for i := range xs {
+			xs[i] = Num(255 - i)
+		}
+		inverses, err := f.InvSlice(xs)
+		if err != nil {
+			t.Errorf("%v: InvSlice: unexpected error: %v", f.Polynomial(), err)
+			continue
+		}
+		for i, x := range xs {
+			if expected, _ := f.Inv(x); expected != inverses[i] {
+				t.Errorf("%v: 1 / %v: expected %v, actual %v.", f.Polynomial(), x, expected, inverses[i])
+			}
+		}
+		if inverses, err := f.InvSlice(nil); err != nil || len(inverses) != 0 {
+			t.Errorf("%v: InvSlice(nil): unexpected result %v, %v.", f.Polynomial(), inverses, err)
+		}
+		if _, err := f.InvSlice([]Num{0x01, 0x00, 0x02}); !errors.Is(err, ErrInvZero) {
+			t.Errorf("%v: InvSlice with zero element: expected ErrInvZero, got %v.", f.Polynomial(), err)
+		}
+	}
+}
+
 func TestMultiplication(t *testing.T) {
 	f, err := NewField(0x11d, 0x02)
 	if err != nil {
@@ -324,3 +673,1219 @@ func TestMultiplication(t *testing.T) {
 		}
 	}
 }
+
+func TestSumAndProduct(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	if sum := f.Sum(); sum != f.Zero() {
+		t.Errorf("Empty sum: expected %v, actual %v.", f.Zero(), sum)
+	}
+	if product := f.Product(); product != f.One() {
+		t.Errorf("Empty product: expected %v, actual %v.", f.One(), product)
+	}
+	testData := [][]Num{
+		{0x53},
+		{0x02, 0x04},
+		{0x05, 0x11, 0x80},
+		{0x7f, 0x19, 0xff, 0xca},
+		{0x7f, 0x00, 0xff, 0xca},
+	}
+	for _, xs := range testData {
+		expectedSum, expectedProduct := f.Zero(), f.One()
+		for _, x := range xs {
+			expectedSum = f.Add(expectedSum, x)
+			expectedProduct = f.Mul(expectedProduct, x)
+		}
+		if actualSum := f.Sum(xs...); expectedSum != actualSum {
+			t.Errorf("Sum(%v): expected %v, actual %v.", xs, expectedSum, actualSum)
+		}
+		if actualProduct := f.Product(xs...); expectedProduct != actualProduct {
+			t.Errorf("Product(%v): expected %v, actual %v.", xs, expectedProduct, actualProduct)
+		}
+	}
+}
+
+func TestDivision(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	for i := uint(1); i < 256; i++ {
+		y := Num(i)
+		inv, err := f.Inv(y)
+		if err != nil {
+			t.Errorf("Error computing inverse of %v: %v", y, err)
+		}
+		if q, err := f.Div(f.Zero(), y); err != nil || q != f.Zero() {
+			t.Errorf("0 / %v: expected 0, got %v (error %v).", y, q, err)
+		}
+		for j := uint(1); j < 256; j++ {
+			x := Num(j)
+			expected := f.Mul(x, inv)
+			actual, err := f.Div(x, y)
+			if err != nil {
+				t.Errorf("%v / %v: got error %v", x, y, err)
+			}
+			if expected != actual {
+				t.Errorf("%v / %v: expected %v, got %v.", x, y, expected, actual)
+			}
+		}
+	}
+	if _, err := f.Div(f.One(), f.Zero()); !errors.Is(err, ErrDivideByZero) {
+		t.Errorf("Expected ErrDivideByZero, got %v.", err)
+	}
+}
+
+func TestExp(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	testData := []struct {
+		exponent int
+		expected Num
+	}{
+		{0, 0x01},
+		{1, 0x02},
+		{254, 0x8e},
+		{255, 0x01},
+		{256, 0x02},
+		{510, 0x01},
+		{511, 0x02},
+		{-1, 0x8e},
+		{-254, 0x02},
+		{-255, 0x01},
+		{-256, 0x8e},
+		{-510, 0x01},
+	}
+	for _, data := range testData {
+		if actual := f.Exp(data.exponent); data.expected != actual {
+			t.Errorf("Exp(%d): expected %v, actual %v.", data.exponent, data.expected, actual)
+		}
+	}
+	for i := -1000; i < 1000; i++ {
+		if f.Exp(i) != f.Exp(i+255) {
+			t.Errorf("Exp(%d) != Exp(%d).", i, i+255)
+		}
+		if i != 0 && f.Mul(f.Exp(i), f.Exp(-i)) != f.One() {
+			t.Errorf("Exp(%d) × Exp(%d) != 1.", i, -i)
+		}
+	}
+}
+
+func TestPow(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	if y := f.Pow(f.Zero(), 0); y != f.One() {
+		t.Errorf("0^0: expected 1, got %v.", y)
+	}
+	if y := f.Pow(f.Zero(), 3); y != f.Zero() {
+		t.Errorf("0^3: expected 0, got %v.", y)
+	}
+	if y := f.Pow(f.Zero(), -3); y != f.Zero() {
+		t.Errorf("0^-3: expected 0, got %v.", y)
+	}
+	for i := uint(1); i < 256; i++ {
+		x := Num(i)
+		if y := f.Pow(x, 0); y != f.One() {
+			t.Errorf("%v^0: expected 1, got %v.", x, y)
+		}
+		if y := f.Pow(x, 255); y != f.One() {
+			t.Errorf("%v^255: expected 1, got %v.", x, y)
+		}
+		if y := f.Pow(x, 256); y != x {
+			t.Errorf("%v^256: expected %v, got %v.", x, x, y)
+		}
+		inv, _ := f.Inv(x)
+		if y := f.Pow(x, -1); y != inv {
+			t.Errorf("%v^-1: expected %v, got %v.", x, inv, y)
+		}
+		if y, expected := f.Pow(x, -3), f.Mul(inv, f.Mul(inv, inv)); y != expected {
+			t.Errorf("%v^-3: expected %v, got %v.", x, expected, y)
+		}
+		expected := f.One()
+		for n := 1; n < 20; n++ {
+			expected = f.Mul(expected, x)
+			if y := f.Pow(x, n); y != expected {
+				t.Errorf("%v^%d: expected %v, got %v.", x, n, expected, y)
+			}
+		}
+	}
+}
+
+func TestLogBase(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	for _, base := range f.Generators() {
+		for i := 1; i < 256; i++ {
+			x := Num(i)
+			log, err := f.LogBase(base, x)
+			if err != nil {
+				t.Errorf("LogBase(%v, %v): unexpected error: %v", base, x, err)
+				continue
+			}
+			if log < 0 || log >= 255 {
+				t.Errorf("LogBase(%v, %v): %d is out of range.", base, x, log)
+			}
+			if y, err := f.ExpBase(base, log); y != x || err != nil {
+				t.Errorf("ExpBase(%v, LogBase(%v, %v)): expected %v, got %v, %v.", base, base, x, x, y, err)
+			}
+		}
+		if log, _ := f.LogBase(base, base); log != 1 {
+			t.Errorf("LogBase(%v, %v): expected 1, got %d.", base, base, log)
+		}
+		if _, err := f.LogBase(base, f.Zero()); !errors.Is(err, ErrLogZero) {
+			t.Errorf("LogBase(%v, 0): expected ErrLogZero, got %v.", base, err)
+		}
+	}
+	// The generator of the field gives the same results as Log and Exp.
+	for i := 1; i < 256; i++ {
+		expected, _ := f.Log(Num(i))
+		if log, _ := f.LogBase(f.Generator(), Num(i)); log != expected {
+			t.Errorf("LogBase(%v, %v): expected %d, got %d.", f.Generator(), Num(i), expected, log)
+		}
+	}
+	for _, base := range []Num{0x00, 0x01, f.Exp(3), f.Exp(85)} {
+		if _, err := f.LogBase(base, 0x02); err == nil {
+			t.Errorf("LogBase(%v, 10): expected error.", base)
+		}
+		if _, err := f.ExpBase(base, 1); err == nil {
+			t.Errorf("ExpBase(%v, 1): expected error.", base)
+		}
+	}
+}
+
+func TestExpAndLogTables(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	expTable, logTable := f.ExpTable(), f.LogTable()
+	if len(expTable) != 255 || len(logTable) != 256 {
+		t.Errorf("Unexpected table sizes %d and %d.", len(expTable), len(logTable))
+		return
+	}
+	for i, x := range expTable {
+		if f.Exp(i) != x {
+			t.Errorf("ExpTable()[%d] == %v, expected %v.", i, x, f.Exp(i))
+		}
+		if logTable[x] != i {
+			t.Errorf("LogTable()[%v] == %d, expected %d.", x, logTable[x], i)
+		}
+	}
+	if logTable[0] != 0 {
+		t.Errorf("LogTable()[0] == %d, expected 0.", logTable[0])
+	}
+	// Mutating the copies must not affect the field.
+	for i := range expTable {
+		expTable[i] = 0
+	}
+	for i := range logTable {
+		logTable[i] = 0
+	}
+	if x := f.Exp(1); x != f.Generator() {
+		t.Errorf("Exp(1) == %v after modifying ExpTable copy.", x)
+	}
+	if log, _ := f.Log(f.Generator()); log != 1 {
+		t.Errorf("Log(%v) == %d after modifying LogTable copy.", f.Generator(), log)
+	}
+	if table := f.ExpTable(); table[1] != f.Generator() {
+		t.Errorf("ExpTable()[1] == %v after modifying an earlier copy.", table[1])
+	}
+}
+
+func TestZechLog(t *testing.T) {
+	for _, f := range []*Field{NewQRField(), NewAESField()} {
+		for i := -300; i < 600; i++ {
+			z, ok := f.ZechLog(i)
+			sum := f.Add(f.One(), f.Exp(i))
+			if !ok {
+				if sum != f.Zero() {
+					t.Errorf("%v: ZechLog(%d) undefined but 1 + %v == %v.", f.Polynomial(), i, f.Exp(i), sum)
+				}
+				continue
+			}
+			if f.Exp(z) != sum {
+				t.Errorf("%v: Exp(ZechLog(%d)) == %v, expected %v.", f.Polynomial(), i, f.Exp(z), sum)
+			}
+		}
+		if _, ok := f.ZechLog(0); ok {
+			t.Errorf("%v: expected ZechLog(0) to be undefined.", f.Polynomial())
+		}
+	}
+	f, err := NewFieldGF2m(4, 0x13, 0x2)
+	if err != nil {
+		t.Errorf("Could not create GF(2^4): %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	for i := 1; i < 15; i++ {
+		if z, ok := f.ZechLog(i); !ok || f.Exp(z) != f.Add(f.One(), f.Exp(i)) {
+			t.Errorf("GF(2^4): ZechLog(%d) == %d, %v.", i, z, ok)
+		}
+	}
+	if _, ok := f.ZechLog(15); ok {
+		t.Errorf("GF(2^4): expected ZechLog(15) to be undefined.")
+	}
+}
+
+func TestSquare(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	for i := uint(0); i < 256; i++ {
+		x := Num(i)
+		if expected, actual := f.Mul(x, x), f.Square(x); expected != actual {
+			t.Errorf("%v²: expected %v, got %v.", x, expected, actual)
+		}
+	}
+}
+
+func TestSqrt(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	for i := uint(0); i < 256; i++ {
+		x := Num(i)
+		if y := f.Sqrt(f.Square(x)); x != y {
+			t.Errorf("√(%v²): expected %v, got %v.", x, x, y)
+		}
+		if y := f.Square(f.Sqrt(x)); x != y {
+			t.Errorf("(√%v)²: expected %v, got %v.", x, x, y)
+		}
+	}
+}
+
+func TestMulConstantTime(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	for i := uint(0); i < 256; i++ {
+		for j := uint(0); j < 256; j++ {
+			x := Num(i)
+			y := Num(j)
+			if expected, actual := f.Mul(x, y), f.MulConstantTime(x, y); expected != actual {
+				t.Errorf("%v × %v: expected %v, got %v.", x, y, expected, actual)
+			}
+		}
+	}
+}
+
+func TestMulTable(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	g, _ := NewField(0x11d, 0x02)
+	g.EnableMulTable()
+	g.EnableMulTable() // Should be a no-op.
+	for i := uint(0); i < 256; i++ {
+		for j := uint(0); j < 256; j++ {
+			x := Num(i)
+			y := Num(j)
+			if expected, actual := f.Mul(x, y), g.Mul(x, y); expected != actual {
+				t.Errorf("%v × %v: expected %v, got %v.", x, y, expected, actual)
+			}
+		}
+	}
+}
+
+// TestConcurrentUse is most useful when run with the -race flag.
+func TestConcurrentUse(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	g, _ := NewField(0x11d, 0x02)
+	p := Polynomial{0x8e, 0x01, 0x00, 0x17, 0x02}
+	var wg sync.WaitGroup
+	for n := 0; n < 16; n++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			if n%4 == 0 {
+				f.EnableMulTable() // Races with Mul unless guarded.
+			}
+			for i := uint(1); i < 256; i++ {
+				x := Num(i)
+				y := Num((int(i)+n)%255 + 1)
+				if expected, actual := g.Mul(x, y), f.Mul(x, y); expected != actual {
+					t.Errorf("%v × %v: expected %v, got %v.", x, y, expected, actual)
+				}
+				if inv, err := f.Inv(x); err != nil || f.Mul(x, inv) != f.One() {
+					t.Errorf("Inv(%v): got %v, %v.", x, inv, err)
+				}
+				if sum := f.Add(x, y); sum != x^y {
+					t.Errorf("%v + %v: expected %v, got %v.", x, y, x^y, sum)
+				}
+				if expected, actual := g.EvaluatePolynomial(p, x), f.EvaluatePolynomial(p, x); expected != actual {
+					t.Errorf("p(%v): expected %v, got %v.", x, expected, actual)
+				}
+			}
+		}(n)
+	}
+	wg.Wait()
+}
+
+func TestMulMatrix(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	for i := uint(0); i < 256; i++ {
+		c := Num(i)
+		matrix := f.MulMatrix(c)
+		for j := uint(0); j < 256; j++ {
+			x := Num(j)
+			// Multiply the matrix by the bit vector x over GF(2).
+			y := Num(0)
+			for k, row := range matrix {
+				y |= Num(bits.OnesCount8(row&uint8(x))&0x01) << uint(k)
+			}
+			if expected := f.Mul(c, x); y != expected {
+				t.Errorf("MulMatrix(%v) applied to %v: expected %v, got %v.", c, x, expected, y)
+			}
+		}
+	}
+	if identity := f.MulMatrix(f.One()); identity != [8]uint8{0x01, 0x02, 0x04, 0x08, 0x10, 0x20, 0x40, 0x80} {
+		t.Errorf("MulMatrix(1): expected identity, got %v.", identity)
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected MulMatrix to panic for GF(2^4).")
+		}
+	}()
+	g, _ := NewFieldGF2m(4, 0x13, 0x2)
+	g.MulMatrix(0x03)
+}
+
+func benchmarkMul(b *testing.B, f *Field) {
+	for n := 0; n < b.N; n++ {
+		for i := uint(0); i < 256; i++ {
+			for j := uint(0); j < 256; j++ {
+				f.Mul(Num(i), Num(j))
+			}
+		}
+	}
+}
+
+func BenchmarkMulLogTable(b *testing.B) {
+	f, _ := NewField(0x11d, 0x02)
+	benchmarkMul(b, f)
+}
+
+func BenchmarkMulTable(b *testing.B) {
+	f, _ := NewField(0x11d, 0x02)
+	f.EnableMulTable()
+	b.ResetTimer()
+	benchmarkMul(b, f)
+}
+
+func BenchmarkNewField(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		NewField(0x11d, 0x02)
+	}
+}
+
+func BenchmarkMultiply(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		for i := uint(0); i < 256; i++ {
+			for j := uint(0); j < 256; j++ {
+				multiply(Num(i), Num(j), 0x11d)
+			}
+		}
+	}
+}
+
+func TestMsb(t *testing.T) {
+	// Bit-by-bit reference implementation.
+	reference := func(n uint) uint {
+		i := uint(0)
+		for {
+			n = n >> 1
+			if n == 0 {
+				return i
+			}
+			i = i + 1
+		}
+	}
+	for n := uint(1); n < 512; n++ {
+		if expected, actual := reference(n), msb(n); expected != actual {
+			t.Errorf("msb(%b): expected %d, actual %d.", n, expected, actual)
+		}
+	}
+}
+
+func TestMulSlice(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	in := make([]Num, 256)
+	for i := range in {
+		in[i] = Num(i)
+	}
+	for i := uint(0); i < 256; i++ {
+		c := Num(i)
+		out := make([]Num, len(in))
+		for j := range out {
+			out[j] = Num(255 - j)
+		}
+		f.MulSlice(c, in, out)
+		for j := range out {
+			expected := f.Add(Num(255-j), f.Mul(c, in[j]))
+			if out[j] != expected {
+				t.Errorf("MulSlice(%v) at %d: expected %v, got %v.", c, j, expected, out[j])
+			}
+		}
+	}
+}
+
+func TestMulSliceLengthMismatch(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected MulSlice to panic on length mismatch.")
+		}
+	}()
+	f.MulSlice(f.One(), make([]Num, 3), make([]Num, 4))
+}
+
+func BenchmarkMulSlice(b *testing.B) {
+	f, _ := NewField(0x11d, 0x02)
+	in := make([]Num, 4096)
+	out := make([]Num, 4096)
+	for i := range in {
+		in[i] = Num(i % 256)
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		f.MulSlice(Num(0x1f), in, out)
+	}
+}
+
+func TestDot(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	testData := []struct {
+		a, b []Num
+	}{
+		{[]Num{}, []Num{}},
+		{[]Num{0x53}, []Num{0xca}},
+		{[]Num{0x01, 0x02, 0x03}, []Num{0x03, 0x02, 0x01}},
+		{[]Num{0x7f, 0x19, 0xff, 0x00}, []Num{0x05, 0x11, 0x80, 0x42}},
+	}
+	for _, data := range testData {
+		expected := f.Zero()
+		for i := range data.a {
+			for j := range data.b {
+				if i == j {
+					expected = f.Add(expected, f.Mul(data.a[i], data.b[j]))
+				}
+			}
+		}
+		actual, err := f.Dot(data.a, data.b)
+		if err != nil {
+			t.Errorf("Dot(%v, %v): unexpected error: %v", data.a, data.b, err)
+		}
+		if expected != actual {
+			t.Errorf("Dot(%v, %v): expected %v, actual %v.", data.a, data.b, expected, actual)
+		}
+	}
+	// Rows of a matrix are orthogonal to the columns of its inverse, apart
+	// from the diagonal.
+	m := f.Vandermonde(4, 4)
+	inv, err := f.MatInvert(m)
+	if err != nil {
+		t.Errorf("Could not invert %v: %v.", m, err)
+		return
+	}
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			row, col := make([]Num, 4), make([]Num, 4)
+			for k := 0; k < 4; k++ {
+				row[k], col[k] = m.At(i, k), inv.At(k, j)
+			}
+			dot, _ := f.Dot(row, col)
+			if i != j && dot != f.Zero() {
+				t.Errorf("Row %d and column %d are not orthogonal: %v.", i, j, dot)
+			}
+		}
+	}
+	if _, err := f.Dot([]Num{0x01}, []Num{0x01, 0x02}); err == nil {
+		t.Errorf("Expected error return value from Dot with mismatched lengths.")
+	}
+}
+
+func TestZeroErrors(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	if _, err := f.Log(f.Zero()); !errors.Is(err, ErrLogZero) {
+		t.Errorf("Expected ErrLogZero, got %v.", err)
+	} else if err.Error() != "gf256: log of zero" {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+	if _, err := f.Inv(f.Zero()); !errors.Is(err, ErrInvZero) {
+		t.Errorf("Expected ErrInvZero, got %v.", err)
+	} else if err.Error() != "gf256: inverse of zero" {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+	if _, err := f.Div(f.One(), f.Zero()); !errors.Is(err, ErrDivideByZero) {
+		t.Errorf("Expected ErrDivideByZero, got %v.", err)
+	} else if err.Error() != "gf256: division by zero" {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+}
+
+func TestNumByte(t *testing.T) {
+	for i := 0; i < 256; i++ {
+		b := byte(i)
+		n := NumFromByte(b)
+		if n != Num(i) {
+			t.Errorf("NumFromByte(%d): got %v.", b, n)
+		}
+		roundTrip, err := n.Byte()
+		if err != nil {
+			t.Errorf("%v.Byte(): got error %v", n, err)
+		}
+		if roundTrip != b {
+			t.Errorf("%v.Byte(): expected %d, got %d.", n, b, roundTrip)
+		}
+	}
+	for _, n := range []Num{0x100, 0x1ff, 1000} {
+		if b, err := n.Byte(); err == nil {
+			t.Errorf("%v.Byte(): expected error, got %d.", n, b)
+		}
+	}
+}
+
+func TestOutOfRange(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	for i := 0; i < 256; i++ {
+		if !Num(i).Valid() {
+			t.Errorf("%v should be valid.", Num(i))
+		}
+	}
+	for _, x := range []Num{256, 1000} {
+		if x.Valid() {
+			t.Errorf("%v should not be valid.", x)
+		}
+		if _, err := f.Log(x); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("Log(%v): expected ErrOutOfRange, got %v.", x, err)
+		}
+		if _, err := f.Inv(x); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("Inv(%v): expected ErrOutOfRange, got %v.", x, err)
+		}
+		if _, err := f.Div(x, f.One()); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("Div(%v, 1): expected ErrOutOfRange, got %v.", x, err)
+		}
+		if _, err := f.Div(f.One(), x); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("Div(1, %v): expected ErrOutOfRange, got %v.", x, err)
+		}
+		for _, enableMulTable := range []bool{false, true} {
+			if enableMulTable {
+				f.EnableMulTable()
+			}
+			func() {
+				defer func() {
+					if r := recover(); r == nil {
+						t.Errorf("Expected Mul(%v, 1) to panic.", x)
+					} else if msg := fmt.Sprint(r); msg != fmt.Sprintf("Mul: %v × 1: gf256: number out of range.", x) {
+						t.Errorf("Unexpected panic message: %v", msg)
+					}
+				}()
+				f.Mul(x, f.One())
+			}()
+		}
+		if order, err := f.OrderOf(x); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("OrderOf(%v): expected ErrOutOfRange, got %d, %v.", x, order, err)
+		}
+		if f.IsPrimitive(x) {
+			t.Errorf("IsPrimitive(%v): expected false.", x)
+		}
+		for _, order := range f.Subfields() {
+			if f.InSubfield(x, order) {
+				t.Errorf("InSubfield(%v, %d): expected false.", x, order)
+			}
+		}
+		if _, err := f.SolveQuadratic(f.One(), f.One(), x); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("SolveQuadratic(1, 1, %v): expected ErrOutOfRange, got %v.", x, err)
+		}
+		if _, err := f.InvSlice([]Num{0x01, x}); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("InvSlice([1 %v]): expected ErrOutOfRange, got %v.", x, err)
+		}
+		if _, err := f.Dot([]Num{0x01, x}, []Num{0x01, 0x01}); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("Dot([1 %v], [1 1]): expected ErrOutOfRange, got %v.", x, err)
+		}
+		if _, err := f.Interpolate([]Num{0x01, x}, []Num{0x01, 0x01}); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("Interpolate([1 %v], [1 1]): expected ErrOutOfRange, got %v.", x, err)
+		}
+		if _, err := f.Interpolate([]Num{0x01, 0x02}, []Num{x, 0x01}); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("Interpolate([1 2], [%v 1]): expected ErrOutOfRange, got %v.", x, err)
+		}
+		panicking := []struct {
+			name string
+			call func()
+		}{
+			{"Pow", func() { f.Pow(x, 5) }},
+			{"Pow", func() { f.Pow(x, 0) }},
+			{"Square", func() { f.Square(x) }},
+			{"Sqrt", func() { f.Sqrt(x) }},
+			{"Frobenius", func() { f.Frobenius(x) }},
+			{"Conjugates", func() { f.Conjugates(x) }},
+			{"Trace", func() { f.Trace(x) }},
+			{"MulSlice", func() { f.MulSlice(x, []Num{0x01}, []Num{0x00}) }},
+			{"MulSlice", func() { f.MulSlice(0x02, []Num{x}, []Num{0x00}) }},
+		}
+		for _, p := range panicking {
+			func() {
+				defer func() {
+					if r := recover(); r == nil {
+						t.Errorf("Expected %s with %v to panic.", p.name, x)
+					} else if msg := fmt.Sprint(r); msg != fmt.Sprintf("%s: %v: gf256: number out of range.", p.name, x) {
+						t.Errorf("Unexpected panic message: %v", msg)
+					}
+				}()
+				p.call()
+			}()
+		}
+	}
+	// Exp accepts every exponent.
+	if x := f.Exp(1000); x != f.Exp(1000%255) {
+		t.Errorf("Exp(1000): expected %v, got %v.", f.Exp(1000%255), x)
+	}
+	// Larger fields accept larger values.
+	g, err := NewFieldGF2m(12, 0x1053, 0x2)
+	if err != nil {
+		t.Errorf("Could not create GF(2^12): %v.", err)
+		return
+	}
+	if _, err := g.Log(1000); err != nil {
+		t.Errorf("GF(2^12): Log(1000): got error %v", err)
+	}
+	if _, err := g.Log(4096); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("GF(2^12): Log(4096): expected ErrOutOfRange, got %v.", err)
+	}
+}
+
+func TestNumFormat(t *testing.T) {
+	testData := []struct {
+		format   string
+		n        Num
+		expected string
+	}{
+		{"%v", 0x00, "0"},
+		{"%v", 0x17, "10111"},
+		{"%s", 0x17, "10111"},
+		{"%b", 0xff, "11111111"},
+		{"%d", 0xff, "255"},
+		{"%x", 0xff, "ff"},
+		{"%X", 0xff, "FF"},
+		{"%o", 0x08, "10"},
+		{"%#x", 0x0a, "0xa"},
+		{"%#v", 0x17, "0x17"},
+		{"%10v", 0x05, "       101"},
+		{"%-6s|", 0x05, "101   |"},
+		{"%010b", 0x05, "0000000101"},
+		{"%+d", 0x05, "+5"},
+		{"%5d", 0x1f, "   31"},
+		{"%-5x|", 0x1f, "1f   |"},
+		{"%04X", 0x1f, "001F"},
+		{"%.4d", 0x1f, "0031"},
+		{"%8.4x", 0x1f, "    001f"},
+		{"%.3v", 0x1f, "111"},
+	}
+	for _, data := range testData {
+		if s := fmt.Sprintf(data.format, data.n); s != data.expected {
+			t.Errorf("Sprintf(%q, %d): expected %q, got %q.", data.format, uint(data.n), data.expected, s)
+		}
+	}
+	// Formatting a Polynomial formats each coefficient.
+	if s := fmt.Sprintf("%x", []Num{0x0a, 0xff}); s != "[a ff]" {
+		t.Errorf("Sprintf(%%x, [a ff]): got %q.", s)
+	}
+}
+
+func TestParseNum(t *testing.T) {
+	for i := uint(0); i < 256; i++ {
+		n, err := ParseNum(Num(i).String())
+		if err != nil {
+			t.Errorf("ParseNum(%v): got error %v", Num(i), err)
+		}
+		if n != Num(i) {
+			t.Errorf("ParseNum(%v): got %v.", Num(i), n)
+		}
+	}
+	for _, s := range []string{"", "2", "100000000", "0000000100000000", "10 1", "-1", "0x1f"} {
+		if n, err := ParseNum(s); err == nil {
+			t.Errorf("ParseNum(%q): expected error, got %v.", s, n)
+		}
+	}
+}
+
+func TestNewQRField(t *testing.T) {
+	f := NewQRField()
+	if f.Polynomial() != 0x11d || f.Generator() != 0x02 {
+		t.Errorf("Unexpected QR field parameters: %v, %v.", f.Polynomial(), f.Generator())
+	}
+	g, _ := NewField(0x11d, 0x02)
+	compareFields(t, f, g)
+}
+
+func ExampleNewFieldFromGenerator() {
+	f, _ := NewFieldFromGenerator(0x03)
+	fmt.Println(f.Polynomial())
+	// Output: x⁸+x⁴+x³+x+1
+}
+
+func TestNewFieldFromGenerator(t *testing.T) {
+	found := 0
+	for g := Num(0x02); g < 0x100; g++ {
+		// Find the first polynomial that works, if any, by brute force.
+		var expected Irreducible
+		for _, p := range IrreduciblePolynomials() {
+			if _, err := NewField(p, g); err == nil {
+				expected = p
+				break
+			}
+		}
+		f, err := NewFieldFromGenerator(g)
+		if expected == 0 {
+			// For example, x³ is never a generator since 3 divides 255.
+			if err == nil {
+				t.Errorf("NewFieldFromGenerator(%v): expected error, got %v.", g, f.Polynomial())
+			}
+			continue
+		}
+		found++
+		if err != nil {
+			t.Errorf("NewFieldFromGenerator(%v): unexpected error %v.", g, err)
+			continue
+		}
+		if f.Generator() != g || f.Polynomial() != expected {
+			t.Errorf("NewFieldFromGenerator(%v): expected %v, %v, got %v, %v.", g, expected, g, f.Polynomial(), f.Generator())
+		}
+		for i := uint(1); i < 256; i++ {
+			x := Num(i)
+			if inv, err := f.Inv(x); err != nil || f.Mul(x, inv) != f.One() {
+				t.Errorf("NewFieldFromGenerator(%v): error multiplying %v by its inverse %v: %v.", g, x, inv, err)
+			}
+			logX, _ := f.Log(x)
+			if y := f.Exp(logX); x != y {
+				t.Errorf("NewFieldFromGenerator(%v): exp(log(%v)) == %v.", g, x, y)
+			}
+		}
+	}
+	if found == 0 {
+		t.Errorf("NewFieldFromGenerator: no generator found a field.")
+	}
+	for _, g := range []Num{0x00, 0x01, 0x08, 0x100} {
+		if _, err := NewFieldFromGenerator(g); err == nil {
+			t.Errorf("NewFieldFromGenerator(%v): expected error, got nil.", g)
+		}
+	}
+}
+
+func TestStandardField(t *testing.T) {
+	f := StandardField()
+	if g := StandardField(); f != g {
+		t.Errorf("StandardField returned different pointers: %p, %p.", f, g)
+	}
+	g, _ := NewField(0x11d, 0x02)
+	compareFields(t, f, g)
+}
+
+func TestNewAESField(t *testing.T) {
+	f := NewAESField()
+	if f.Polynomial() != 0x11b || f.Generator() != 0x03 {
+		t.Errorf("Unexpected AES field parameters: %v, %v.", f.Polynomial(), f.Generator())
+	}
+	// Examples from FIPS-197, sections 4.2 and 4.2.1.
+	testData := []struct {
+		factor1, factor2, expectedProduct Num
+	}{
+		{0x57, 0x83, 0xc1},
+		{0x57, 0x02, 0xae},
+		{0x57, 0x04, 0x47},
+		{0x57, 0x08, 0x8e},
+		{0x57, 0x10, 0x07},
+		{0x57, 0x13, 0xfe},
+	}
+	for _, data := range testData {
+		if actual := f.Mul(data.factor1, data.factor2); actual != data.expectedProduct {
+			t.Errorf("%v × %v: expected %v, actual %v.", data.factor1, data.factor2, data.expectedProduct, actual)
+		}
+	}
+}
+
+func TestCyclotomicCosets(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	cosets := CyclotomicCosets()
+	if len(cosets) != 35 {
+		t.Errorf("Expected 35 cosets, got %d.", len(cosets))
+	}
+	seen := make([]bool, 255)
+	for _, coset := range cosets {
+		if 8%len(coset) != 0 {
+			t.Errorf("Coset %v has size %d, which does not divide 8.", coset, len(coset))
+		}
+		for _, i := range coset {
+			if i < 0 || i >= 255 || seen[i] {
+				t.Errorf("Coset %v: %d is out of range or already seen.", coset, i)
+				continue
+			}
+			seen[i] = true
+		}
+		conjugates := f.Conjugates(f.Exp(coset[0]))
+		if len(conjugates) != len(coset) {
+			t.Errorf("Coset %v: expected %d conjugates, got %v.", coset, len(coset), conjugates)
+			continue
+		}
+		for j, i := range coset {
+			if f.Exp(i) != conjugates[j] {
+				t.Errorf("Coset %v: expected conjugates %v.", coset, conjugates)
+				break
+			}
+		}
+	}
+	for i, ok := range seen {
+		if !ok {
+			t.Errorf("%d is not in any coset.", i)
+		}
+	}
+}
+
+func TestTrace(t *testing.T) {
+	for _, data := range []struct{ m, polynomial uint }{{3, 0xb}, {4, 0x13}, {8, 0x11d}} {
+		m := data.m
+		f, err := NewFieldGF2m(m, data.polynomial, 0x02)
+		if err != nil {
+			t.Errorf("Could not create GF(2^%d): %v.", m, err)
+			continue
+		}
+		ones := 0
+		for _, x := range f.Elements() {
+			switch tr := f.Trace(x); tr {
+			case f.Zero():
+			case f.One():
+				ones++
+			default:
+				t.Errorf("GF(2^%d): Trace(%v): expected 0 or 1, got %v.", m, x, tr)
+			}
+			if y := f.Square(x); f.Trace(y) != f.Trace(x) {
+				t.Errorf("GF(2^%d): Trace(%v) != Trace(%v).", m, y, x)
+			}
+		}
+		// The trace is a surjective linear map to GF(2).
+		if expected := f.Cardinality() / 2; ones != expected {
+			t.Errorf("GF(2^%d): expected %d elements with trace 1, got %d.", m, expected, ones)
+		}
+	}
+}
+
+func TestSolveQuadratic(t *testing.T) {
+	testData := []struct {
+		m, polynomial uint
+		coefficients  []Num
+	}{
+		{3, 0xb, []Num{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07}},
+		{8, 0x11d, []Num{0x00, 0x01, 0x02, 0x07, 0x1d, 0x53, 0x80, 0xca, 0xff}},
+	}
+	for _, data := range testData {
+		m := data.m
+		f, err := NewFieldGF2m(m, data.polynomial, 0x02)
+		if err != nil {
+			t.Errorf("Could not create GF(2^%d): %v.", m, err)
+			continue
+		}
+		for _, a := range data.coefficients {
+			for _, b := range data.coefficients {
+				for _, c := range data.coefficients {
+					if a == f.Zero() && b == f.Zero() {
+						if _, err := f.SolveQuadratic(a, b, c); err == nil {
+							t.Errorf("SolveQuadratic(0, 0, %v): expected error, got nil.", c)
+						}
+						continue
+					}
+					roots, err := f.SolveQuadratic(a, b, c)
+					if err != nil {
+						t.Errorf("SolveQuadratic(%v, %v, %v): unexpected error %v.", a, b, c, err)
+						continue
+					}
+					// Compare against an exhaustive search.
+					var expected []Num
+					for _, x := range f.Elements() {
+						if f.Sum(f.Mul(a, f.Square(x)), f.Mul(b, x), c) == f.Zero() {
+							expected = append(expected, x)
+						}
+					}
+					if fmt.Sprint(roots) != fmt.Sprint(expected) {
+						t.Errorf("GF(2^%d): SolveQuadratic(%v, %v, %v): expected %v, got %v.", m, a, b, c, expected, roots)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestConjugates(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	for i := uint(0); i < 256; i++ {
+		x := Num(i)
+		if f.Frobenius(x) != f.Mul(x, x) {
+			t.Errorf("Frobenius(%v): expected %v, got %v.", x, f.Mul(x, x), f.Frobenius(x))
+		}
+		conjugates := f.Conjugates(x)
+		if 8%len(conjugates) != 0 {
+			t.Errorf("Conjugates(%v): size %d does not divide 8.", x, len(conjugates))
+		}
+		seen := make(map[Num]bool)
+		for _, y := range conjugates {
+			if seen[y] {
+				t.Errorf("Conjugates(%v): duplicate %v.", x, y)
+			}
+			seen[y] = true
+		}
+		// Elements of the subfields GF(2), GF(2²), and GF(2⁴) have
+		// orbits of size at most 1, 2, and 4, respectively.
+		for _, subfield := range []struct{ order, maxSize int }{{2, 1}, {4, 2}, {16, 4}} {
+			if f.Pow(x, subfield.order) == x && len(conjugates) > subfield.maxSize {
+				t.Errorf("Conjugates(%v): expected at most %d conjugates, got %v.", x, subfield.maxSize, conjugates)
+			}
+		}
+	}
+}
+
+func TestOrderOf(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	if _, err := f.OrderOf(f.Zero()); err == nil {
+		t.Errorf("Expected error for order of zero.")
+	}
+	for i := uint(1); i < 256; i++ {
+		x := Num(i)
+		order, err := f.OrderOf(x)
+		if err != nil {
+			t.Errorf("OrderOf(%v): got error %v", x, err)
+		}
+		expected := 1
+		for y := x; y != f.One(); y = f.Mul(y, x) {
+			expected++
+		}
+		if order != expected {
+			t.Errorf("OrderOf(%v): expected %d, got %d.", x, expected, order)
+		}
+		if 255%order != 0 {
+			t.Errorf("OrderOf(%v): %d does not divide 255.", x, order)
+		}
+	}
+	if order, _ := f.OrderOf(f.Generator()); order != 255 {
+		t.Errorf("OrderOf(generator): expected 255, got %d.", order)
+	}
+	if order, _ := f.OrderOf(f.One()); order != 1 {
+		t.Errorf("OrderOf(1): expected 1, got %d.", order)
+	}
+	if order, _ := f.OrderOf(f.Exp(3)); order != 85 {
+		t.Errorf("OrderOf(α³): expected 85, got %d.", order)
+	}
+}
+
+func TestElements(t *testing.T) {
+	for _, f := range []*Field{NewQRField(), NewAESField()} {
+		elements := f.Elements()
+		if len(elements) != 256 {
+			t.Errorf("%v: expected 256 elements, got %d.", f.Polynomial(), len(elements))
+		}
+		for i, x := range elements {
+			if x != Num(i) {
+				t.Errorf("%v: element %d is %v.", f.Polynomial(), i, x)
+			}
+		}
+		powers := f.PowersOfGenerator()
+		if len(powers) != 255 {
+			t.Errorf("%v: expected 255 powers, got %d.", f.Polynomial(), len(powers))
+		}
+		seen := make(map[Num]bool)
+		for i, x := range powers {
+			if x != f.Exp(i) {
+				t.Errorf("%v: power %d is %v, expected %v.", f.Polynomial(), i, x, f.Exp(i))
+			}
+			if seen[x] {
+				t.Errorf("%v: %v appears twice.", f.Polynomial(), x)
+			}
+			seen[x] = true
+		}
+		if seen[f.Zero()] || len(seen) != 255 {
+			t.Errorf("%v: powers do not cover the non-zero elements.", f.Polynomial())
+		}
+	}
+}
+
+func TestGenerators(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	generators := f.Generators()
+	// Euler's totient of 255 == 3×5×17 is 2×4×16 == 128.
+	if len(generators) != 128 {
+		t.Errorf("Expected 128 generators, got %d.", len(generators))
+	}
+	found := false
+	for _, g := range generators {
+		if g == f.Generator() {
+			found = true
+		}
+		if _, err := NewField(f.Polynomial(), g); err != nil {
+			t.Errorf("Generator %v rejected by NewField: %v", g, err)
+		}
+	}
+	if !found {
+		t.Errorf("Generator %v not among %v.", f.Generator(), generators)
+	}
+	for _, x := range []Num{0x00, 0x01, 0x08, f.Exp(5), f.Exp(17)} {
+		if f.IsPrimitive(x) {
+			t.Errorf("IsPrimitive(%v): expected false.", x)
+		}
+	}
+}
+
+func TestNewFieldGF2mWithBadParameters(t *testing.T) {
+	testData := []struct {
+		m                     uint
+		polynomial, generator uint
+		message               string
+	}{
+		{1, 0x3, 0x1, "GF(2^1) is not supported."},
+		{17, 0x2002d, 0x2, "GF(2^17) is not supported."},
+		{4, 0x9, 0x2, "x³+1 has too low degree."},
+		{4, 0x25, 0x2, "x⁵+x²+1 has too high degree."},
+		{4, 0x15, 0x2, "x⁴+x²+1 is reducible."},
+		{4, 0x13, 0x10, "10000 is not a generator."},
+		{4, 0x1f, 0x2, "10 is not a generator."},
+	}
+	for _, data := range testData {
+		_, err := NewFieldGF2m(data.m, data.polynomial, data.generator)
+		if err == nil {
+			t.Errorf("Expected error return value from NewFieldGF2m(%d, %x, %x).", data.m, data.polynomial, data.generator)
+			continue
+		}
+		if err.Error() != data.message {
+			t.Errorf("Unexpected error message: %v", err)
+		}
+	}
+}
+
+func TestGF2mArithmeticOperators(t *testing.T) {
+	testData := []struct {
+		m                     uint
+		polynomial, generator uint
+	}{
+		{2, 0x7, 0x2},
+		{3, 0xb, 0x2},
+		{4, 0x13, 0x2},
+		{5, 0x25, 0x2},
+		{8, 0x11d, 0x2},
+		{12, 0x1053, 0x2},
+	}
+	for _, data := range testData {
+		f, err := NewFieldGF2m(data.m, data.polynomial, data.generator)
+		if err != nil {
+			t.Errorf("Could not create GF(2^%d): %v.", data.m, err)
+			continue
+		}
+		size := uint(1) << data.m
+		// Test every element for small fields and a sample otherwise.
+		step := uint(1)
+		if size > 256 {
+			step = 37
+		}
+		for i := uint(0); i < size; i += step {
+			x := Num(i)
+			if y := f.Add(x, f.Zero()); x != y {
+				t.Errorf("GF(2^%d): error adding with zero: expected %v, got %v.", data.m, x, y)
+			}
+			if y := f.Mul(x, f.Zero()); f.Zero() != y {
+				t.Errorf("GF(2^%d): error multiplying by zero: expected %v, got %v.", data.m, f.Zero(), y)
+			}
+			if y := f.Mul(x, f.One()); x != y {
+				t.Errorf("GF(2^%d): error multiplying by one: expected %v, got %v.", data.m, x, y)
+			}
+			if y := f.Pow(x, int(size)); x != y {
+				t.Errorf("GF(2^%d): %v^%d: expected %v, got %v.", data.m, x, size, x, y)
+			}
+			if y := f.Square(f.Sqrt(x)); x != y {
+				t.Errorf("GF(2^%d): (√%v)²: expected %v, got %v.", data.m, x, x, y)
+			}
+			if x != f.Zero() {
+				inv, err := f.Inv(x)
+				if err != nil {
+					t.Errorf("GF(2^%d): error computing inverse of %v: %v", data.m, x, err)
+				}
+				if y := f.Mul(x, inv); f.One() != y {
+					t.Errorf("GF(2^%d): error multiplying %v by its inverse: expected 1, got %v.", data.m, x, y)
+				}
+			}
+			for j := uint(0); j < size; j += step {
+				y := Num(j)
+				product := f.Mul(x, y)
+				if product >= Num(size) {
+					t.Errorf("GF(2^%d): %v × %v == %v is out of range.", data.m, x, y, product)
+				}
+				if expected := multiply(x, y, f.Polynomial()); expected != product {
+					t.Errorf("GF(2^%d): %v × %v: expected %v, got %v.", data.m, x, y, expected, product)
+				}
+				if ct := f.MulConstantTime(x, y); ct != product {
+					t.Errorf("GF(2^%d): constant-time %v × %v: expected %v, got %v.", data.m, x, y, product, ct)
+				}
+			}
+		}
+	}
+}
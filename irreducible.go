@@ -0,0 +1,59 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gf256
+
+// IrreduciblePolynomials returns, in ascending order, all 30 irreducible
+// polynomials of degree eight over GF(2), any of which can be used to
+// define GF[2⁸].
+func IrreduciblePolynomials() []Irreducible {
+	var polynomials []Irreducible
+	for p := Irreducible(0x100); p < 0x200; p++ {
+		if IsIrreducible(p) {
+			polynomials = append(polynomials, p)
+		}
+	}
+	return polynomials
+}
+
+// IsIrreducible returns true if p is a polynomial of degree eight that is
+// irreducible over GF(2), i.e. if p can be used to define GF[2⁸].
+func IsIrreducible(p Irreducible) bool {
+	if p|0x1FF != 0x1FF || p&0x100 == 0 {
+		return false
+	}
+	return !hasFactor(uint(p), 8)
+}
+
+// hasFactor returns true if the degree-m bit-vector polynomial p is
+// divisible by some polynomial over GF(2) of degree one to m/2. Any
+// factorization of p has a factor of degree at most m/2.
+func hasFactor(p uint, m uint) bool {
+	for d := uint(0x02); d < 1<<(m/2+1); d++ {
+		if bitmaskMod(p, d) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// bitmaskMod returns the remainder when dividing the bit-vector
+// polynomial a by the non-zero bit-vector polynomial b over GF(2).
+func bitmaskMod(a, b uint) uint {
+	bMsb := msb(b)
+	for a != 0 && msb(a) >= bMsb {
+		a = a ^ (b << (msb(a) - bMsb))
+	}
+	return a
+}
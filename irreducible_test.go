@@ -0,0 +1,71 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gf256
+
+import "testing"
+
+func TestIrreduciblePolynomials(t *testing.T) {
+	polynomials := IrreduciblePolynomials()
+	if len(polynomials) != 30 {
+		t.Errorf("Expected 30 irreducible polynomials, got %d: %v.", len(polynomials), polynomials)
+	}
+	found := make(map[Irreducible]bool)
+	for _, p := range polynomials {
+		found[p] = true
+		// Every irreducible polynomial defines the field, so some
+		// element must be a generator.
+		ok := false
+		for g := Num(2); g < 256 && !ok; g++ {
+			_, err := NewField(p, g)
+			ok = err == nil
+		}
+		if !ok {
+			t.Errorf("No generator found for %v.", p)
+		}
+	}
+	for _, p := range []Irreducible{0x11d, 0x11b} {
+		if !found[p] {
+			t.Errorf("%v is not in the list of irreducible polynomials.", p)
+		}
+	}
+	for _, p := range []Irreducible{0x101, 0x100, 0x1ff} {
+		if found[p] {
+			t.Errorf("%v is reducible but in the list.", p)
+		}
+	}
+}
+
+func TestIsIrreducible(t *testing.T) {
+	testData := []struct {
+		p           Irreducible
+		irreducible bool
+	}{
+		{0x101, false}, // x⁸+1 == (x+1)⁸.
+		{0x100, false}, // x⁸.
+		{0x1ff, false}, // (x²+x+1)(x⁶+x³+1).
+		{0x11d, true},
+		{0x11b, true},
+		{0x12b, true},
+		{0x163, true},
+		{0x1f5, true},
+		{0x03, false},  // Too low degree.
+		{0x211, false}, // Too high degree.
+	}
+	for _, data := range testData {
+		if actual := IsIrreducible(data.p); actual != data.irreducible {
+			t.Errorf("IsIrreducible(%v): expected %v, got %v.", data.p, data.irreducible, actual)
+		}
+	}
+}
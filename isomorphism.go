@@ -0,0 +1,56 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gf256
+
+import "fmt"
+
+// Isomorphism returns a function that maps elements of the field f to the
+// corresponding elements of the field g, preserving addition and
+// multiplication, or an error if f and g have different sizes. The element
+// x of f, whose bits are the coefficients of a polynomial in x modulo the
+// irreducible polynomial of f, is mapped to the same polynomial evaluated
+// at a root β of that irreducible polynomial in g. The smallest such root is
+// used; other roots give different isomorphisms, which differ by a
+// Frobenius automorphism. The returned function must only be called with
+// elements of f.
+func (f *Field) Isomorphism(g *Field) (func(Num) Num, error) {
+	if f.m != g.m {
+		return nil, fmt.Errorf("Isomorphism: GF(2^%d) and GF(2^%d) are not isomorphic.", f.m, g.m)
+	}
+	p := make(Polynomial, f.m+1)
+	for i := range p {
+		p[i] = Num(uint(f.poly)>>uint(i)) & g.One()
+	}
+	roots := g.Roots(p)
+	if len(roots) == 0 {
+		// Cannot happen: every irreducible polynomial of degree m over
+		// GF(2) splits in GF(2^m).
+		return nil, fmt.Errorf("Isomorphism: %v has no root in the field defined by %v.", f.poly, g.poly)
+	}
+	// images[i] == β^i is the image of x^i.
+	images := make([]Num, f.m)
+	for i := range images {
+		images[i] = g.Pow(roots[0], i)
+	}
+	return func(x Num) Num {
+		y := g.Zero()
+		for i, image := range images {
+			if x>>uint(i)&0x01 != 0 {
+				y = g.Add(y, image)
+			}
+		}
+		return y
+	}, nil
+}
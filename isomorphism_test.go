@@ -0,0 +1,84 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gf256
+
+import "fmt"
+import "testing"
+
+func ExampleField_Isomorphism() {
+	qr, aes := NewQRField(), NewAESField()
+	toAES, _ := qr.Isomorphism(aes)
+	x, y := Num(0x0a), Num(0x1f)
+	fmt.Println(toAES(qr.Mul(x, y)) == aes.Mul(toAES(x), toAES(y)))
+	// Output:
+	// true
+}
+
+func TestIsomorphism(t *testing.T) {
+	fields := []*Field{NewQRField(), NewAESField()}
+	for _, p := range []Irreducible{0x12b, 0x187} {
+		f, err := NewField(p, 0x02)
+		if err != nil {
+			t.Errorf("Could not create field: %v.", err)
+			return // Avoid crashing due to dereferencing nil below.
+		}
+		fields = append(fields, f)
+	}
+	for _, f := range fields {
+		for _, g := range fields {
+			phi, err := f.Isomorphism(g)
+			if err != nil {
+				t.Errorf("%v → %v: unexpected error: %v", f.Polynomial(), g.Polynomial(), err)
+				continue
+			}
+			seen := make(map[Num]bool)
+			for i := 0; i < 256; i++ {
+				seen[phi(Num(i))] = true
+			}
+			if len(seen) != 256 {
+				t.Errorf("%v → %v: not a bijection.", f.Polynomial(), g.Polynomial())
+			}
+			if phi(f.Zero()) != g.Zero() || phi(f.One()) != g.One() {
+				t.Errorf("%v → %v: zero or one not preserved.", f.Polynomial(), g.Polynomial())
+			}
+			for i := 0; i < 256; i++ {
+				for j := 0; j < 256; j++ {
+					a, b := Num(i), Num(j)
+					if phi(f.Add(a, b)) != g.Add(phi(a), phi(b)) {
+						t.Errorf("%v → %v: sum of %v and %v not preserved.", f.Polynomial(), g.Polynomial(), a, b)
+					}
+					if phi(f.Mul(a, b)) != g.Mul(phi(a), phi(b)) {
+						t.Errorf("%v → %v: product of %v and %v not preserved.", f.Polynomial(), g.Polynomial(), a, b)
+					}
+				}
+			}
+		}
+		// A field is mapped to itself by the identity.
+		phi, _ := f.Isomorphism(f)
+		for i := 0; i < 256; i++ {
+			if phi(Num(i)) != Num(i) {
+				t.Errorf("%v → itself: %v mapped to %v.", f.Polynomial(), Num(i), phi(Num(i)))
+			}
+		}
+	}
+	small, err := NewFieldGF2m(4, 0x13, 0x2)
+	if err != nil {
+		t.Errorf("Could not create GF(2^4): %v.", err)
+		return
+	}
+	if _, err := small.Isomorphism(NewQRField()); err == nil {
+		t.Errorf("Expected error return value from fields of different sizes.")
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gf256
+
+// LFSR is a Galois linear feedback shift register over GF(2). Its state is a
+// polynomial of degree less than its length, and each step multiplies the
+// state by x modulo the feedback polynomial. If the feedback polynomial is
+// primitive and the seed is non-zero, the register cycles through all 2^length-1
+// non-zero states, producing a maximal-length sequence.
+type LFSR struct {
+	taps   uint
+	state  uint
+	length uint
+}
+
+// NewLFSR returns an LFSR of the given length with feedback polynomial taps,
+// represented as a bitmask in the same way as Irreducible, and initial state
+// seed. The x^length term of taps is implied if missing, and bits of seed at
+// positions length and above are ignored.
+func NewLFSR(taps uint, seed uint, length uint) *LFSR {
+	mask := uint(1)<<length - 1
+	return &LFSR{
+		taps:   taps | 1<<length,
+		state:  seed & mask,
+		length: length,
+	}
+}
+
+// State returns the current state of the register.
+func (r *LFSR) State() uint {
+	return r.state
+}
+
+// Next clocks the register one step and returns the output bit, which is
+// the bit shifted out of the high end of the register.
+func (r *LFSR) Next() uint {
+	r.state = r.state << 1
+	if msb(r.state) < r.length {
+		return 0
+	}
+	// Reduce modulo the feedback polynomial. Since the state had degree
+	// less than length before shifting, a single subtraction suffices.
+	r.state = r.state ^ r.taps
+	return 1
+}
+
+// Sequence clocks the register n times and returns the output bits.
+func (r *LFSR) Sequence(n int) []uint {
+	bits := make([]uint, n)
+	for i := range bits {
+		bits[i] = r.Next()
+	}
+	return bits
+}
@@ -0,0 +1,95 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gf256
+
+import "fmt"
+import "testing"
+
+func ExampleLFSR() {
+	r := NewLFSR(0x13, 0x1, 4)
+	fmt.Println(r.Sequence(15))
+	fmt.Println(r.State())
+	// Output:
+	// [0 0 0 1 0 0 1 1 0 1 0 1 1 1 1]
+	// 1
+}
+
+// period returns the smallest p such that bits[i] == bits[i+p] for all i.
+func period(bits []uint) int {
+	for p := 1; p < len(bits); p++ {
+		ok := true
+		for i := 0; i+p < len(bits) && ok; i++ {
+			ok = bits[i] == bits[i+p]
+		}
+		if ok {
+			return p
+		}
+	}
+	return len(bits)
+}
+
+func TestLFSRPeriod(t *testing.T) {
+	testData := []struct {
+		taps           uint
+		length         uint
+		expectedPeriod int
+	}{
+		{0x7, 2, 3},
+		{0x13, 4, 15},
+		{0x1f, 4, 5}, // Irreducible but not primitive.
+		{0x11d, 8, 255},
+		{0x1d, 8, 255}, // Implied x⁸ term.
+		{0x12b, 8, 255},
+		{0x11b, 8, 51}, // x has order 51 modulo the AES polynomial.
+	}
+	for _, data := range testData {
+		r := NewLFSR(data.taps, 0x1, data.length)
+		if p := period(r.Sequence(3 * data.expectedPeriod)); p != data.expectedPeriod {
+			t.Errorf("LFSR(%x, %d): expected period %d, got %d.", data.taps, data.length, data.expectedPeriod, p)
+		}
+	}
+}
+
+func TestLFSRMatchesField(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	// Clocking the register multiplies its state by x, so the states are
+	// the powers of the generator x, and every non-zero state occurs once.
+	r := NewLFSR(0x11d, 0x1, 8)
+	seen := make(map[uint]bool)
+	for i := 0; i < 255; i++ {
+		if state := Num(r.State()); state != f.Exp(i) {
+			t.Errorf("State after %d steps: expected %v, got %v.", i, f.Exp(i), state)
+		}
+		seen[r.State()] = true
+		r.Next()
+	}
+	if len(seen) != 255 || seen[0] {
+		t.Errorf("Expected all 255 non-zero states, got %d.", len(seen))
+	}
+	if r.State() != 0x1 {
+		t.Errorf("State after 255 steps: expected 1, got %d.", r.State())
+	}
+	// The all-zero state is a fixed point.
+	r = NewLFSR(0x11d, 0x100, 8)
+	for i, bit := range r.Sequence(16) {
+		if bit != 0 || r.State() != 0 {
+			t.Errorf("Zero seed: unexpected output %d at step %d.", bit, i)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gf256
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrSingularMatrix is returned when inverting a matrix that has no inverse.
+var ErrSingularMatrix = errors.New("gf256: singular matrix")
+
+// Matrix represents a matrix with entries in GF[2⁸]. Like a slice, a
+// Matrix value refers to underlying storage, so copies of a Matrix value
+// share their entries.
+type Matrix struct {
+	rows, cols int
+	// entries holds the entries in row-major order.
+	entries []Num
+}
+
+// NewMatrix returns a rows×cols matrix with all entries zero. NewMatrix
+// panics if rows or cols is negative.
+func (f *Field) NewMatrix(rows, cols int) Matrix {
+	if rows < 0 || cols < 0 {
+		panic(fmt.Sprintf("NewMatrix: negative dimensions %d×%d.", rows, cols))
+	}
+	m := Matrix{rows: rows, cols: cols, entries: make([]Num, rows*cols)}
+	for i := range m.entries {
+		m.entries[i] = f.Zero()
+	}
+	return m
+}
+
+// ZeroMatrix returns the rows×cols matrix with all entries zero. It is the
+// same as NewMatrix.
+func (f *Field) ZeroMatrix(rows, cols int) Matrix {
+	return f.NewMatrix(rows, cols)
+}
+
+// Identity returns the n×n identity matrix.
+func (f *Field) Identity(n int) Matrix {
+	m := f.NewMatrix(n, n)
+	for i := 0; i < n; i++ {
+		m.Set(i, i, f.One())
+	}
+	return m
+}
+
+// Rows returns the number of rows of m.
+func (m Matrix) Rows() int {
+	return m.rows
+}
+
+// Cols returns the number of columns of m.
+func (m Matrix) Cols() int {
+	return m.cols
+}
+
+// At returns the entry in row i and column j of m. At panics if i or j is
+// out of range.
+func (m Matrix) At(i, j int) Num {
+	m.checkIndex("At", i, j)
+	return m.entries[i*m.cols+j]
+}
+
+// Set sets the entry in row i and column j of m to n. Set panics if i or j
+// is out of range.
+func (m Matrix) Set(i, j int, n Num) {
+	m.checkIndex("Set", i, j)
+	m.entries[i*m.cols+j] = n
+}
+
+// checkIndex panics if row i or column j is outside m. Without it, an out of
+// range column would silently address an entry in another row.
+func (m Matrix) checkIndex(name string, i, j int) {
+	if i < 0 || i >= m.rows || j < 0 || j >= m.cols {
+		panic(fmt.Sprintf("%s: index (%d, %d) out of range for %d×%d matrix.", name, i, j, m.rows, m.cols))
+	}
+}
+
+// Transpose returns a new matrix whose entry in row i and column j is the
+// entry in row j and column i of m.
+func (m Matrix) Transpose() Matrix {
+	t := Matrix{rows: m.cols, cols: m.rows, entries: make([]Num, len(m.entries))}
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < m.cols; j++ {
+			t.Set(j, i, m.At(i, j))
+		}
+	}
+	return t
+}
+
+// String returns a readable string representation of m with one row per line.
+func (m Matrix) String() string {
+	s := ""
+	for i := 0; i < m.rows; i++ {
+		if i != 0 {
+			s = s + "\n"
+		}
+		s = s + fmt.Sprint(m.entries[i*m.cols:(i+1)*m.cols])
+	}
+	return s
+}
+
+// MatAdd returns a+b, or an error if a and b have different dimensions.
+func (f *Field) MatAdd(a, b Matrix) (Matrix, error) {
+	if a.rows != b.rows || a.cols != b.cols {
+		return Matrix{}, fmt.Errorf("MatAdd: dimension mismatch: %d×%d + %d×%d.",
+			a.rows, a.cols, b.rows, b.cols)
+	}
+	sum := f.NewMatrix(a.rows, a.cols)
+	for i := range sum.entries {
+		sum.entries[i] = f.Add(a.entries[i], b.entries[i])
+	}
+	return sum, nil
+}
+
+// MatMul returns a×b, or an error if the number of columns of a differs
+// from the number of rows of b.
+func (f *Field) MatMul(a, b Matrix) (Matrix, error) {
+	if a.cols != b.rows {
+		return Matrix{}, fmt.Errorf("MatMul: dimension mismatch: %d×%d × %d×%d.",
+			a.rows, a.cols, b.rows, b.cols)
+	}
+	product := f.NewMatrix(a.rows, b.cols)
+	for i := 0; i < a.rows; i++ {
+		for j := 0; j < b.cols; j++ {
+			sum := f.Zero()
+			for k := 0; k < a.cols; k++ {
+				sum = f.Add(sum, f.Mul(a.At(i, k), b.At(k, j)))
+			}
+			product.Set(i, j, sum)
+		}
+	}
+	return product, nil
+}
+
+// MatInvert returns the inverse of the square matrix m, or an error if m is
+// not square or is singular.
+func (f *Field) MatInvert(m Matrix) (Matrix, error) {
+	if m.rows != m.cols {
+		return Matrix{}, fmt.Errorf("MatInvert: %d×%d matrix is not square.", m.rows, m.cols)
+	}
+	n := m.rows
+	// The code below implements Gauss-Jordan elimination on a working
+	// copy of m, applying the same row operations to the identity matrix.
+	a := f.NewMatrix(n, n)
+	copy(a.entries, m.entries)
+	inverse := f.Identity(n)
+	for col := 0; col < n; col++ {
+		pivot := col
+		for pivot < n && a.At(pivot, col) == f.Zero() {
+			pivot++
+		}
+		if pivot == n {
+			return Matrix{}, fmt.Errorf("%w: column %d has no pivot", ErrSingularMatrix, col)
+		}
+		a.swapRows(pivot, col)
+		inverse.swapRows(pivot, col)
+		scale, _ := f.Inv(a.At(col, col))
+		for j := 0; j < n; j++ {
+			a.Set(col, j, f.Mul(a.At(col, j), scale))
+			inverse.Set(col, j, f.Mul(inverse.At(col, j), scale))
+		}
+		for i := 0; i < n; i++ {
+			factor := a.At(i, col)
+			if i == col || factor == f.Zero() {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				a.Set(i, j, f.Add(a.At(i, j), f.Mul(factor, a.At(col, j))))
+				inverse.Set(i, j, f.Add(inverse.At(i, j), f.Mul(factor, inverse.At(col, j))))
+			}
+		}
+	}
+	return inverse, nil
+}
+
+// Solve returns the solution x of the linear system a×x = b, where a is a
+// square matrix and b has one entry per row of a. It returns an error if a
+// is not square, if b has the wrong length, or if a is singular.
+func (f *Field) Solve(a Matrix, b []Num) ([]Num, error) {
+	if a.rows != a.cols {
+		return nil, fmt.Errorf("Solve: %d×%d matrix is not square.", a.rows, a.cols)
+	}
+	if len(b) != a.rows {
+		return nil, fmt.Errorf("Solve: length mismatch: %d != %d.", len(b), a.rows)
+	}
+	n := a.rows
+	// The code below implements Gaussian elimination on the augmented
+	// matrix [a | b], taking any non-zero entry as the pivot, followed by
+	// back substitution.
+	m := f.NewMatrix(n, n+1)
+	for i := 0; i < n; i++ {
+		copy(m.entries[i*(n+1):i*(n+1)+n], a.entries[i*n:(i+1)*n])
+		m.Set(i, n, b[i])
+	}
+	for col := 0; col < n; col++ {
+		pivot := col
+		for pivot < n && m.At(pivot, col) == f.Zero() {
+			pivot++
+		}
+		if pivot == n {
+			return nil, fmt.Errorf("%w: column %d has no pivot", ErrSingularMatrix, col)
+		}
+		m.swapRows(pivot, col)
+		inv, _ := f.Inv(m.At(col, col))
+		for i := col + 1; i < n; i++ {
+			factor := f.Mul(m.At(i, col), inv)
+			if factor == f.Zero() {
+				continue
+			}
+			for j := col; j <= n; j++ {
+				m.Set(i, j, f.Add(m.At(i, j), f.Mul(factor, m.At(col, j))))
+			}
+		}
+	}
+	x := make([]Num, n)
+	for i := n - 1; i >= 0; i-- {
+		sum := m.At(i, n)
+		for j := i + 1; j < n; j++ {
+			sum = f.Add(sum, f.Mul(m.At(i, j), x[j]))
+		}
+		x[i], _ = f.Div(sum, m.At(i, i))
+	}
+	return x, nil
+}
+
+// swapRows exchanges rows i and j of m.
+func (m Matrix) swapRows(i, j int) {
+	if i == j {
+		return
+	}
+	for k := 0; k < m.cols; k++ {
+		m.entries[i*m.cols+k], m.entries[j*m.cols+k] = m.entries[j*m.cols+k], m.entries[i*m.cols+k]
+	}
+}
+
+// Vandermonde returns the rows×cols Vandermonde matrix whose entry in row i
+// and column j is x_i^j for x_i = α^i, i.e. α^(i×j), where α is the
+// generator of f. The x_i are distinct for rows ≤ 255, or rows ≤ 2^m-1 in
+// GF(2^m), so any cols of the rows then form an invertible square matrix.
+func (f *Field) Vandermonde(rows, cols int) Matrix {
+	m := f.NewMatrix(rows, cols)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			m.Set(i, j, f.Exp(i*j))
+		}
+	}
+	return m
+}
+
+// Cauchy returns the rows×cols Cauchy matrix whose entry in row i and
+// column j is 1/(x_i+y_j) for x_i = i and y_j = rows+j, viewed as elements
+// of f. Every square submatrix of a Cauchy matrix is invertible. Cauchy
+// returns an error if rows+cols exceeds the number of elements of f.
+func (f *Field) Cauchy(rows, cols int) (Matrix, error) {
+	if rows < 0 || cols < 0 || rows+cols > len(f.logTable) {
+		return Matrix{}, fmt.Errorf("Cauchy: cannot build %d×%d matrix over GF(2^%d).", rows, cols, f.m)
+	}
+	m := f.NewMatrix(rows, cols)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			// x_i ≠ y_j, so their sum is non-zero.
+			n, _ := f.Inv(f.Add(Num(i), Num(rows+j)))
+			m.Set(i, j, n)
+		}
+	}
+	return m, nil
+}
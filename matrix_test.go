@@ -0,0 +1,408 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gf256
+
+import "errors"
+import "fmt"
+import "math/rand"
+import "testing"
+
+// randomMatrix returns a rows×cols matrix with random entries.
+func randomMatrix(f *Field, rows, cols int, random *rand.Rand) Matrix {
+	m := f.NewMatrix(rows, cols)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			m.Set(i, j, Num(random.Intn(256)))
+		}
+	}
+	return m
+}
+
+func ExampleMatrix() {
+	f, _ := NewField(0x11d, 0x2)
+	a := f.NewMatrix(2, 2)
+	a.Set(0, 0, 0x01)
+	a.Set(0, 1, 0x02)
+	a.Set(1, 0, 0x03)
+	a.Set(1, 1, 0x04)
+	sum, _ := f.MatAdd(a, a)
+	product, _ := f.MatMul(a, a)
+	fmt.Println(a)
+	fmt.Println(sum)
+	fmt.Println(product)
+	// Output:
+	// [1 10]
+	// [11 100]
+	// [0 0]
+	// [0 0]
+	// [111 1010]
+	// [1111 10110]
+}
+
+func TestMatMulIdentity(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	random := rand.New(rand.NewSource(1))
+	m := randomMatrix(f, 3, 5, random)
+	left, err := f.MatMul(f.Identity(3), m)
+	if err != nil {
+		t.Errorf("I×m: got error %v", err)
+	}
+	right, err := f.MatMul(m, f.Identity(5))
+	if err != nil {
+		t.Errorf("m×I: got error %v", err)
+	}
+	if left.String() != m.String() {
+		t.Errorf("I×m: expected\n%v\ngot\n%v", m, left)
+	}
+	if right.String() != m.String() {
+		t.Errorf("m×I: expected\n%v\ngot\n%v", m, right)
+	}
+}
+
+func TestIdentityAndZeroMatrix(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	id := f.Identity(3)
+	if expected := "[1 0 0]\n[0 1 0]\n[0 0 1]"; id.String() != expected {
+		t.Errorf("Identity(3): expected\n%v\ngot\n%v", expected, id)
+	}
+	zero := f.ZeroMatrix(2, 3)
+	if zero.Rows() != 2 || zero.Cols() != 3 || zero.String() != "[0 0 0]\n[0 0 0]" {
+		t.Errorf("ZeroMatrix(2, 3): got\n%v", zero)
+	}
+	random := rand.New(rand.NewSource(1))
+	m := randomMatrix(f, 2, 3, random)
+	if sum, _ := f.MatAdd(m, zero); sum.String() != m.String() {
+		t.Errorf("m+0: expected\n%v\ngot\n%v", m, sum)
+	}
+	if product, _ := f.MatMul(zero, f.Identity(3)); product.String() != zero.String() {
+		t.Errorf("0×I: expected\n%v\ngot\n%v", zero, product)
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	random := rand.New(rand.NewSource(1))
+	for _, dims := range [][2]int{{1, 1}, {1, 4}, {3, 5}, {4, 4}} {
+		m := randomMatrix(f, dims[0], dims[1], random)
+		transpose := m.Transpose()
+		if transpose.Rows() != m.Cols() || transpose.Cols() != m.Rows() {
+			t.Errorf("Transpose of %d×%d matrix has dimensions %d×%d.", m.Rows(), m.Cols(), transpose.Rows(), transpose.Cols())
+			continue
+		}
+		for i := 0; i < m.Rows(); i++ {
+			for j := 0; j < m.Cols(); j++ {
+				if m.At(i, j) != transpose.At(j, i) {
+					t.Errorf("Transpose of\n%v\ngot\n%v", m, transpose)
+				}
+			}
+		}
+		if twice := transpose.Transpose(); twice.String() != m.String() {
+			t.Errorf("Transposing twice: expected\n%v\ngot\n%v", m, twice)
+		}
+		// (a×b)ᵀ == bᵀ×aᵀ.
+		b := randomMatrix(f, dims[1], 2, random)
+		ab, _ := f.MatMul(m, b)
+		btat, _ := f.MatMul(b.Transpose(), transpose)
+		if ab.Transpose().String() != btat.String() {
+			t.Errorf("(a×b)ᵀ != bᵀ×aᵀ:\n%v\n%v", ab.Transpose(), btat)
+		}
+		// The transpose does not share storage with m.
+		transpose.Set(0, 0, f.Add(transpose.At(0, 0), f.One()))
+		if m.At(0, 0) == transpose.At(0, 0) {
+			t.Errorf("Transpose shares entries with the original matrix.")
+		}
+	}
+}
+
+func TestMatMulAssociativity(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	random := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 20; trial++ {
+		a := randomMatrix(f, 2, 3, random)
+		b := randomMatrix(f, 3, 4, random)
+		c := randomMatrix(f, 4, 2, random)
+		ab, _ := f.MatMul(a, b)
+		bc, _ := f.MatMul(b, c)
+		abc1, _ := f.MatMul(ab, c)
+		abc2, _ := f.MatMul(a, bc)
+		if abc1.String() != abc2.String() {
+			t.Errorf("(ab)c != a(bc):\n%v\n%v", abc1, abc2)
+		}
+	}
+}
+
+func TestMatAdd(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	random := rand.New(rand.NewSource(1))
+	a := randomMatrix(f, 3, 4, random)
+	b := randomMatrix(f, 3, 4, random)
+	sum, err := f.MatAdd(a, b)
+	if err != nil {
+		t.Errorf("a+b: got error %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 4; j++ {
+			if expected := a.At(i, j) ^ b.At(i, j); sum.At(i, j) != expected {
+				t.Errorf("(a+b)[%d][%d]: expected %v, got %v.", i, j, expected, sum.At(i, j))
+			}
+		}
+	}
+}
+
+func TestMatrixDimensionMismatch(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	if _, err := f.MatAdd(f.NewMatrix(2, 3), f.NewMatrix(3, 2)); err == nil {
+		t.Errorf("Expected error adding 2×3 and 3×2 matrices.")
+	}
+	if _, err := f.MatMul(f.NewMatrix(2, 3), f.NewMatrix(2, 3)); err == nil {
+		t.Errorf("Expected error multiplying 2×3 and 2×3 matrices.")
+	}
+}
+
+func TestMatrixIndexOutOfRange(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	m := f.NewMatrix(2, 3)
+	panicking := []struct {
+		name string
+		call func()
+	}{
+		{"At(0, 3)", func() { m.At(0, 3) }},
+		{"At(2, 0)", func() { m.At(2, 0) }},
+		{"At(-1, 0)", func() { m.At(-1, 0) }},
+		{"Set(0, 3)", func() { m.Set(0, 3, f.One()) }},
+		{"Set(1, -1)", func() { m.Set(1, -1, f.One()) }},
+		{"NewMatrix(-1, 2)", func() { f.NewMatrix(-1, 2) }},
+		{"NewMatrix(2, -1)", func() { f.NewMatrix(2, -1) }},
+	}
+	for _, p := range panicking {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Expected %s to panic.", p.name)
+				}
+			}()
+			p.call()
+		}()
+	}
+}
+
+func TestMatInvert(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	random := rand.New(rand.NewSource(1))
+	for n := 1; n <= 8; n++ {
+		for trial := 0; trial < 10; trial++ {
+			m := randomMatrix(f, n, n, random)
+			inverse, err := f.MatInvert(m)
+			if errors.Is(err, ErrSingularMatrix) {
+				continue // Random matrices are occasionally singular.
+			}
+			if err != nil {
+				t.Errorf("Inverting\n%v\ngot error %v", m, err)
+				continue
+			}
+			product, _ := f.MatMul(m, inverse)
+			if product.String() != f.Identity(n).String() {
+				t.Errorf("m×m⁻¹ is not the identity:\n%v", product)
+			}
+			product, _ = f.MatMul(inverse, m)
+			if product.String() != f.Identity(n).String() {
+				t.Errorf("m⁻¹×m is not the identity:\n%v", product)
+			}
+		}
+	}
+}
+
+func TestMatInvertSingular(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	// The second row is α times the first row.
+	m := f.NewMatrix(3, 3)
+	for j, n := range []Num{0x01, 0x17, 0x80} {
+		m.Set(0, j, n)
+		m.Set(1, j, f.Mul(f.Generator(), n))
+		m.Set(2, j, Num(j+1))
+	}
+	if _, err := f.MatInvert(m); !errors.Is(err, ErrSingularMatrix) {
+		t.Errorf("Expected ErrSingularMatrix, got %v.", err)
+	}
+	if _, err := f.MatInvert(f.NewMatrix(2, 2)); !errors.Is(err, ErrSingularMatrix) {
+		t.Errorf("Expected ErrSingularMatrix for zero matrix, got %v.", err)
+	}
+	if _, err := f.MatInvert(f.NewMatrix(2, 3)); err == nil {
+		t.Errorf("Expected error for non-square matrix.")
+	}
+}
+
+func ExampleField_Solve() {
+	f, _ := NewField(0x11d, 0x2)
+	// x + y == 3 and x + 2y == 5 have the solution x == 1, y == 2.
+	a := f.NewMatrix(2, 2)
+	a.Set(0, 0, 0x01)
+	a.Set(0, 1, 0x01)
+	a.Set(1, 0, 0x01)
+	a.Set(1, 1, 0x02)
+	x, _ := f.Solve(a, []Num{0x03, 0x05})
+	fmt.Println(x)
+	// Output: [1 10]
+}
+
+func TestSolve(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	random := rand.New(rand.NewSource(1))
+	for n := 1; n <= 8; n++ {
+		for trial := 0; trial < 10; trial++ {
+			a := randomMatrix(f, n, n, random)
+			expected := randomMatrix(f, n, 1, random)
+			b, _ := f.MatMul(a, expected)
+			x, err := f.Solve(a, b.entries)
+			inverse, invErr := f.MatInvert(a)
+			if errors.Is(invErr, ErrSingularMatrix) {
+				if !errors.Is(err, ErrSingularMatrix) {
+					t.Errorf("Solving with singular\n%v\nexpected ErrSingularMatrix, got %v", a, err)
+				}
+				continue // Random matrices are occasionally singular.
+			}
+			if err != nil {
+				t.Errorf("Solving with\n%v\ngot error %v", a, err)
+				continue
+			}
+			if fmt.Sprint(x) != fmt.Sprint(expected.entries) {
+				t.Errorf("Solving with\n%v\nexpected %v, got %v", a, expected.entries, x)
+			}
+			// Cross-check against multiplying by the inverse.
+			product, _ := f.MatMul(inverse, b)
+			if fmt.Sprint(x) != fmt.Sprint(product.entries) {
+				t.Errorf("Solving with\n%v\nexpected a⁻¹×b == %v, got %v", a, product.entries, x)
+			}
+		}
+	}
+	// The second row is α times the first row.
+	m := f.NewMatrix(3, 3)
+	for j, n := range []Num{0x01, 0x17, 0x80} {
+		m.Set(0, j, n)
+		m.Set(1, j, f.Mul(f.Generator(), n))
+		m.Set(2, j, Num(j+1))
+	}
+	if _, err := f.Solve(m, []Num{0x01, 0x02, 0x03}); !errors.Is(err, ErrSingularMatrix) {
+		t.Errorf("Expected ErrSingularMatrix, got %v.", err)
+	}
+	if _, err := f.Solve(f.NewMatrix(2, 3), []Num{0x01, 0x02}); err == nil {
+		t.Errorf("Expected error for non-square matrix.")
+	}
+	if _, err := f.Solve(f.Identity(2), []Num{0x01}); err == nil {
+		t.Errorf("Expected error for length mismatch.")
+	}
+}
+
+func TestVandermonde(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	// Entries are α^(i×j): α^0 = 1, α = 10, α² = 100, α⁴ = 10000.
+	expected := "[1 1 1]\n[1 10 100]\n[1 100 10000]"
+	if m := f.Vandermonde(3, 3); m.String() != expected {
+		t.Errorf("Vandermonde(3, 3): expected\n%v\ngot\n%v", expected, m)
+	}
+	// Any square submatrix formed from distinct rows is invertible.
+	random := rand.New(rand.NewSource(1))
+	m := f.Vandermonde(20, 6)
+	for trial := 0; trial < 50; trial++ {
+		sub := f.NewMatrix(6, 6)
+		for i, row := range random.Perm(20)[:6] {
+			for j := 0; j < 6; j++ {
+				sub.Set(i, j, m.At(row, j))
+			}
+		}
+		if _, err := f.MatInvert(sub); err != nil {
+			t.Errorf("Inverting\n%v\ngot error %v", sub, err)
+		}
+	}
+}
+
+func TestCauchy(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	m, err := f.Cauchy(10, 12)
+	if err != nil {
+		t.Errorf("Cauchy(10, 12): got error %v", err)
+		return
+	}
+	// Every square submatrix of a Cauchy matrix is invertible.
+	random := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 100; trial++ {
+		n := 1 + random.Intn(10)
+		rows := random.Perm(10)[:n]
+		cols := random.Perm(12)[:n]
+		sub := f.NewMatrix(n, n)
+		for i, row := range rows {
+			for j, col := range cols {
+				sub.Set(i, j, m.At(row, col))
+			}
+		}
+		if _, err := f.MatInvert(sub); err != nil {
+			t.Errorf("Inverting\n%v\ngot error %v", sub, err)
+		}
+	}
+	if _, err := f.Cauchy(128, 128); err != nil {
+		t.Errorf("Cauchy(128, 128): got error %v", err)
+	}
+	if _, err := f.Cauchy(128, 129); err == nil {
+		t.Errorf("Expected error for Cauchy(128, 129).")
+	}
+}
@@ -14,11 +14,29 @@
 
 package gf256
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrDivideByZeroPolynomial is returned when dividing by the zero polynomial.
+	ErrDivideByZeroPolynomial = errors.New("gf256: division by zero polynomial")
+	// ErrZeroPolynomial is returned when an operation is undefined for
+	// the zero polynomial.
+	ErrZeroPolynomial = errors.New("gf256: zero polynomial")
+	// ErrNotARoot is returned when deflating a polynomial by a value that
+	// is not one of its roots.
+	ErrNotARoot = errors.New("gf256: not a root")
+)
 
 // Polynomial represents a polynomial with coefficients in GF[2⁸].
 // The representation is an array slice of Num values: position i
-// in the array slice holds the coefficient for x^i.
+// in the array slice holds the coefficient for x^i. A nil or empty
+// Polynomial represents the zero polynomial.
 type Polynomial []Num
 
 // IsIdenticalZero returns true is p is the zero polynomial.
@@ -31,7 +49,10 @@ func (f *Field) IsIdenticalZero(p Polynomial) bool {
 	return true
 }
 
-// Normalize removes redundant initial zero coefficients from p.
+// Normalize returns a copy of p with redundant initial zero coefficients
+// removed. The zero polynomial, including a nil or empty p, normalizes to
+// Polynomial{0}. The result never shares its backing array with p, so
+// modifying one does not affect the other.
 func (f *Field) Normalize(p Polynomial) Polynomial {
 	i := len(p) - 1
 	for ; i > 0; i-- {
@@ -39,9 +60,26 @@ func (f *Field) Normalize(p Polynomial) Polynomial {
 			break
 		}
 	}
-	// At this point, i==0 or p[i]!=f.Zero(), or both.
-	// We want to keep elements up to and including position i.
-	return p[:i+1]
+	// At this point, i<=0 or p[i]!=f.Zero(), or both.
+	// We want to keep elements up to and including position i, and we
+	// always keep at least the constant term.
+	if i < 0 {
+		i = 0
+	}
+	normalized := make(Polynomial, i+1)
+	copy(normalized, p)
+	return normalized
+}
+
+// Degree returns the degree of p, ignoring redundant high-order zero
+// coefficients, or -1 if p is the zero polynomial.
+func (f *Field) Degree(p Polynomial) int {
+	for i := len(p) - 1; i >= 0; i-- {
+		if p[i] != f.Zero() {
+			return i
+		}
+	}
+	return -1
 }
 
 // EvaluatePolynomial evaluates the polynomial p at point x.
@@ -55,8 +93,120 @@ func (f *Field) EvaluatePolynomial(p Polynomial, x Num) Num {
 	return result
 }
 
-// AddPolynomials returns p1+p2.
+// NewEvaluator returns a function that evaluates polynomials at the point x,
+// like EvaluatePolynomial. The powers of x are computed once, up front, so
+// that each evaluation needs only one multiplication per coefficient.
+// NewEvaluator panics if x is not an element of f.
+func (f *Field) NewEvaluator(x Num) func(Polynomial) Num {
+	f.checkRange("NewEvaluator", x)
+	if x == f.Zero() {
+		return func(p Polynomial) Num {
+			if len(p) == 0 {
+				return f.Zero()
+			}
+			return p[0]
+		}
+	}
+	// The powers of x repeat with period equal to the order of x, so one
+	// cycle suffices for polynomials of any degree.
+	order, _ := f.OrderOf(x)
+	powers := make([]Num, order)
+	power := f.One()
+	for i := range powers {
+		powers[i] = power
+		power = f.Mul(power, x)
+	}
+	return func(p Polynomial) Num {
+		result := f.Zero()
+		j := 0
+		for _, coefficient := range p {
+			result = f.Add(result, f.Mul(coefficient, powers[j]))
+			if j++; j == len(powers) {
+				j = 0
+			}
+		}
+		return result
+	}
+}
+
+// EvaluateAll evaluates the polynomial p at every point in xs and returns
+// the values in the same order.
+func (f *Field) EvaluateAll(p Polynomial, xs []Num) []Num {
+	// Redundant high-order zero coefficients are dropped once, up front, so
+	// that Horner's rule below does no wasted work at each point.
+	p = p[:f.Degree(p)+1]
+	values := make([]Num, len(xs))
+	for i, x := range xs {
+		value := f.Zero()
+		for j := len(p) - 1; j >= 0; j-- {
+			value = f.Add(f.Mul(value, x), p[j])
+		}
+		values[i] = value
+	}
+	return values
+}
+
+// Roots returns, in ascending order, every x in the field such that p
+// evaluates to zero at x. Repeated roots are reported once.
+func (f *Field) Roots(p Polynomial) []Num {
+	var roots []Num
+	if f.EvaluatePolynomial(p, f.Zero()) == f.Zero() {
+		roots = append(roots, f.Zero())
+	}
+	// The code below implements Chien search: when examining α^i,
+	// terms[j] holds p[j]×α^(i×j), so moving on to α^(i+1) only
+	// requires multiplying each term by α^j.
+	terms := make([]Num, len(p))
+	copy(terms, p)
+	steps := make([]Num, len(p))
+	for j := range steps {
+		steps[j] = f.Exp(j)
+	}
+	for i := range f.expTable {
+		sum := f.Zero()
+		for _, term := range terms {
+			sum = f.Add(sum, term)
+		}
+		if sum == f.Zero() {
+			roots = append(roots, f.Exp(i))
+		}
+		for j := range terms {
+			terms[j] = f.Mul(terms[j], steps[j])
+		}
+	}
+	sort.Slice(roots, func(i, j int) bool { return roots[i] < roots[j] })
+	return roots
+}
+
+// CountRoots returns the number of distinct roots of p in the field, which
+// is len(Roots(p)), without finding the roots. It computes the degree of
+// gcd(p, x^q - x), where q is the size of the field. Every element is a root
+// of the zero polynomial.
+func (f *Field) CountRoots(p Polynomial) int {
+	if f.IsIdenticalZero(p) {
+		return f.Cardinality()
+	}
+	x := Polynomial{f.Zero(), f.One()}
+	h, _ := f.PowModPolynomial(x, f.Cardinality(), p)
+	g, _ := f.GCD(p, f.AddPolynomials(h, x))
+	return f.Degree(g)
+}
+
+// AddPolynomials returns p1+p2. The result has as many coefficients as the
+// longer of p1 and p2 and is not normalized, so it may have redundant
+// high-order zero coefficients when terms cancel; callers such as RSEncode
+// rely on this to keep positional coefficients. Use AddPolynomialsNorm for
+// a normalized result.
 func (f *Field) AddPolynomials(p1, p2 Polynomial) (sum Polynomial) {
+	return f.AddPolynomialsInto(nil, p1, p2)
+}
+
+// AddPolynomialsInto computes p1+p2 like AddPolynomials but stores the sum
+// in dst, which is resliced to the length of the longer of p1 and p2, and
+// returns it. A new slice is allocated only if dst has too small capacity.
+// The destination dst may be p1 or p2, in which case that input is
+// overwritten, but must not otherwise overlap either input.
+func (f *Field) AddPolynomialsInto(dst, p1, p2 Polynomial) Polynomial {
 	length := 0
 	if length < len(p1) {
 		length = len(p1)
@@ -64,21 +214,45 @@ func (f *Field) AddPolynomials(p1, p2 Polynomial) (sum Polynomial) {
 	if length < len(p2) {
 		length = len(p2)
 	}
-	sum = make([]Num, length)
-	for i := range sum {
-		sum[i] = f.Zero()
+	if cap(dst) < length {
+		dst = make([]Num, length)
+	}
+	dst = dst[:length]
+	for i := range dst {
+		// Read both inputs before writing, in case dst aliases one of them.
+		sum := f.Zero()
 		if i < len(p1) {
-			sum[i] = f.Add(sum[i], p1[i])
+			sum = f.Add(sum, p1[i])
 		}
 		if i < len(p2) {
-			sum[i] = f.Add(sum[i], p2[i])
+			sum = f.Add(sum, p2[i])
 		}
+		dst[i] = sum
 	}
-	return sum
+	return dst
 }
 
-// MultiplyPolynomials returns p1×p2.
+// AddPolynomialsNorm returns p1+p2 like AddPolynomials, but normalized, so
+// that for example p+p is Polynomial{0}.
+func (f *Field) AddPolynomialsNorm(p1, p2 Polynomial) Polynomial {
+	return f.Normalize(f.AddPolynomials(p1, p2))
+}
+
+// SubPolynomials returns p1-p2, which is identical to p1+p2 since the
+// coefficients are in a field of characteristic two.
+func (f *Field) SubPolynomials(p1, p2 Polynomial) Polynomial {
+	return f.AddPolynomials(p1, p2)
+}
+
+// MultiplyPolynomials returns p1×p2. The result has len(p1)+len(p2)-1
+// coefficients and is not normalized; in particular, multiplying by a zero
+// polynomial gives a slice of zeroes. Use MultiplyPolynomialsNorm for a
+// normalized result. If p1 or p2 is nil or empty, the result is
+// Polynomial{0}.
 func (f *Field) MultiplyPolynomials(p1, p2 Polynomial) (product Polynomial) {
+	if len(p1) == 0 || len(p2) == 0 {
+		return Polynomial{f.Zero()}
+	}
 	// The code below implements long multiplication using addition and multiplication
 	// in the Galois field used for the polynomial coefficients.
 	product = make([]Num, len(p1)+len(p2)-1)
@@ -93,99 +267,397 @@ func (f *Field) MultiplyPolynomials(p1, p2 Polynomial) (product Polynomial) {
 	return product
 }
 
+// MultiplyPolynomialsNorm returns p1×p2 like MultiplyPolynomials, but
+// normalized, so that multiplying by the zero polynomial gives Polynomial{0}.
+func (f *Field) MultiplyPolynomialsNorm(p1, p2 Polynomial) Polynomial {
+	return f.Normalize(f.MultiplyPolynomials(p1, p2))
+}
+
+// PowPolynomial returns p raised to the power n, normalized. PowPolynomial
+// returns the constant polynomial one when n==0, and panics if n<0 since
+// polynomials of positive degree have no multiplicative inverse.
+func (f *Field) PowPolynomial(p Polynomial, n int) Polynomial {
+	if n < 0 {
+		panic(fmt.Sprintf("PowPolynomial: negative exponent %d.", n))
+	}
+	// The code below implements square-and-multiply, normalizing at each step
+	// to keep the intermediate results from growing redundant zero terms.
+	result := Polynomial{f.One()}
+	square := f.Normalize(p)
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			result = f.Normalize(f.MultiplyPolynomials(result, square))
+		}
+		if n > 1 {
+			square = f.Normalize(f.MultiplyPolynomials(square, square))
+		}
+	}
+	return result
+}
+
+// PowModPolynomial returns p raised to the power n, modulo m. It returns an
+// error if n<0 or if m is the zero polynomial.
+func (f *Field) PowModPolynomial(p Polynomial, n int, m Polynomial) (Polynomial, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("PowModPolynomial: negative exponent %d.", n)
+	}
+	// Reducing the constant one also catches the case where m is zero.
+	result, err := f.ModPolynomial(Polynomial{f.One()}, m)
+	if err != nil {
+		return nil, err
+	}
+	square, _ := f.ModPolynomial(p, m)
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			result, _ = f.ModPolynomial(f.MultiplyPolynomials(result, square), m)
+		}
+		if n > 1 {
+			square, _ = f.ModPolynomial(f.MultiplyPolynomials(square, square), m)
+		}
+	}
+	return f.Normalize(result), nil
+}
+
+// ScalePolynomial returns the polynomial c×p, obtained by multiplying every
+// coefficient of p by c. The result is normalized, so scaling by zero yields
+// the zero polynomial.
+func (f *Field) ScalePolynomial(c Num, p Polynomial) Polynomial {
+	scaled := make(Polynomial, len(p))
+	for i, n := range p {
+		scaled[i] = f.Mul(c, n)
+	}
+	return f.Normalize(scaled)
+}
+
+// MakeMonic returns p divided by its leading coefficient, so that the result
+// has leading coefficient one, or an error if p is the zero polynomial.
+// Redundant high-order zero coefficients of p are ignored.
+func (f *Field) MakeMonic(p Polynomial) (Polynomial, error) {
+	if f.IsIdenticalZero(p) {
+		return nil, fmt.Errorf("%w: MakeMonic(%v)", ErrZeroPolynomial, p)
+	}
+	p = f.Normalize(p)
+	inv, _ := f.Inv(p[len(p)-1])
+	return f.ScalePolynomial(inv, p), nil
+}
+
+// ShiftPolynomial returns p×x^k for k>=0. For k<0, it returns p divided by
+// x^(-k), discarding the -k lowest-order coefficients of p; the result is the
+// zero polynomial if p has no more than -k coefficients.
+func (f *Field) ShiftPolynomial(p Polynomial, k int) Polynomial {
+	if k < 0 {
+		if len(p) <= -k {
+			return Polynomial{f.Zero()}
+		}
+		shifted := make(Polynomial, len(p)+k)
+		copy(shifted, p[-k:])
+		return shifted
+	}
+	shifted := make(Polynomial, len(p)+k)
+	for i := 0; i < k; i++ {
+		shifted[i] = f.Zero()
+	}
+	copy(shifted[k:], p)
+	return shifted
+}
+
+// Derivative returns the formal derivative of p. In characteristic two,
+// the coefficient of x^i in the derivative is p[i+1] for even i and zero
+// for odd i.
+func (f *Field) Derivative(p Polynomial) Polynomial {
+	if len(p) <= 1 {
+		return Polynomial{f.Zero()}
+	}
+	derivative := make(Polynomial, len(p)-1)
+	for i := range derivative {
+		derivative[i] = f.Zero()
+		if i%2 == 0 {
+			derivative[i] = p[i+1]
+		}
+	}
+	return f.Normalize(derivative)
+}
+
 // DividePolynomials returns the quotient and remainder when dividing
 // nom by den, or an error if den is the zero polynomial.
 func (f *Field) DividePolynomials(nom, den Polynomial) (quot, rem Polynomial, err error) {
 	if f.IsIdenticalZero(den) {
-		return nil, nil, fmt.Errorf("Division by zero polynomial: %v.", nom)
+		return nil, nil, fmt.Errorf("%w: %v", ErrDivideByZeroPolynomial, nom)
+	}
+	den = f.Normalize(den) // Ensure non-zero highest-order coefficient.
+	if len(nom) < len(den) {
+		return Polynomial{f.Zero()}, f.Normalize(nom), nil
+	}
+	rem = nom.Clone()
+	quot = Polynomial(make([]Num, len(nom)-len(den)+1))
+	f.longDivision(rem, den, quot)
+	return quot, f.Normalize(rem), nil
+}
+
+// QuotientPolynomial returns the quotient when dividing nom by den,
+// discarding the remainder, or an error if den is the zero polynomial.
+func (f *Field) QuotientPolynomial(nom, den Polynomial) (Polynomial, error) {
+	quot, _, err := f.DividePolynomials(nom, den)
+	return quot, err
+}
+
+// ModPolynomial returns the remainder when dividing nom by den, or an error
+// if den is the zero polynomial. It is equivalent to the remainder returned
+// by DividePolynomials but does not compute the quotient.
+func (f *Field) ModPolynomial(nom, den Polynomial) (Polynomial, error) {
+	if f.IsIdenticalZero(den) {
+		return nil, fmt.Errorf("%w: %v", ErrDivideByZeroPolynomial, nom)
 	}
 	den = f.Normalize(den) // Ensure non-zero highest-order coefficient.
 	if len(nom) < len(den) {
-		return Polynomial{f.Zero()}, nom, nil
+		return f.Normalize(nom), nil
+	}
+	rem := nom.Clone()
+	f.longDivision(rem, den, nil)
+	return f.Normalize(rem), nil
+}
+
+// Divides returns true if den divides nom evenly, i.e. if the remainder
+// of nom divided by den is the zero polynomial, or an error if den is the
+// zero polynomial.
+func (f *Field) Divides(den, nom Polynomial) (bool, error) {
+	rem, err := f.ModPolynomial(nom, den)
+	if err != nil {
+		return false, err
+	}
+	return f.IsIdenticalZero(rem), nil
+}
+
+// EvalSynthetic returns p(r) together with the quotient of p divided by
+// (x-r), so that p == quotient×(x-r) + value. Both are computed in a single
+// pass of synthetic division, since the partial sums of Horner's rule are
+// the coefficients of the quotient. The quotient of a constant is zero.
+func (f *Field) EvalSynthetic(p Polynomial, r Num) (value Num, quotient Polynomial) {
+	p = f.Normalize(p)
+	if len(p) == 1 {
+		return p[0], Polynomial{f.Zero()}
+	}
+	quotient = Polynomial(make([]Num, len(p)-1))
+	value = p[len(p)-1]
+	for i := len(p) - 2; i >= 0; i-- {
+		quotient[i] = value
+		value = f.Add(f.Mul(value, r), p[i])
+	}
+	return value, quotient
+}
+
+// DeflateRoot returns the quotient of p divided by (x-r), or an error if r
+// is not a root of p. The degree of the result is one less than that of p.
+func (f *Field) DeflateRoot(p Polynomial, r Num) (Polynomial, error) {
+	if f.IsIdenticalZero(p) {
+		return nil, fmt.Errorf("%w: DeflateRoot(%v, %v)", ErrZeroPolynomial, p, r)
+	}
+	value, quotient := f.EvalSynthetic(p, r)
+	if value != f.Zero() {
+		return nil, fmt.Errorf("%w: DeflateRoot(%v, %v)", ErrNotARoot, p, r)
+	}
+	return quotient, nil
+}
+
+// Shift returns the Taylor shift p(x+a) of p. Unlike ShiftPolynomial, which
+// multiplies by a power of x, Shift translates the argument of p.
+func (f *Field) Shift(p Polynomial, a Num) Polynomial {
+	// The code below implements the Ruffini-Horner method: the remainders
+	// from repeatedly dividing p by (x-a) are the coefficients of p written
+	// in powers of (x-a), and hence the coefficients of p(x+a).
+	p = f.Normalize(p)
+	shifted := Polynomial(make([]Num, len(p)))
+	for i := range shifted {
+		shifted[i], p = f.EvalSynthetic(p, a)
 	}
+	return f.Normalize(shifted)
+}
+
+// longDivision divides rem by the normalized polynomial den in place,
+// leaving the remainder in rem. If quot is not nil, it receives the
+// len(rem)-len(den)+1 coefficients of the quotient.
+func (f *Field) longDivision(rem, den, quot Polynomial) {
 	// The code below implements long division using addition and multiplication
 	// in the Galois field used for the polynomial coefficients.
-	rem = Polynomial(make([]Num, len(nom)))
-	for i, n := range nom {
-		rem[i] = n
-	}
-	degreeDiff := len(nom) - len(den)
-	quot = Polynomial(make([]Num, degreeDiff+1))
 	dInv, _ := f.Inv(den[len(den)-1])
-	for i := len(quot) - 1; i >= 0; i-- {
-		quot[i] = f.Mul(rem[i+len(den)-1], dInv)
-		for j, n := range den {
-			rem[i+j] = f.Add(rem[i+j], f.Mul(quot[i], n))
+	for i := len(rem) - len(den); i >= 0; i-- {
+		q := f.Mul(rem[i+len(den)-1], dInv)
+		if quot != nil {
+			quot[i] = q
+		}
+		if q == f.Zero() {
+			continue // ScalePolynomial would return the shorter zero polynomial.
+		}
+		for j, n := range f.ScalePolynomial(q, den) {
+			rem[i+j] = f.Add(rem[i+j], n)
 		}
 	}
-	return quot, f.Normalize(rem), nil
+}
+
+// GCD returns the monic greatest common divisor of a and b, or an error if
+// both a and b are the zero polynomial.
+func (f *Field) GCD(a, b Polynomial) (Polynomial, error) {
+	if f.IsIdenticalZero(a) && f.IsIdenticalZero(b) {
+		return nil, fmt.Errorf("%w: GCD(%v, %v)", ErrZeroPolynomial, a, b)
+	}
+	// The code below implements the Euclidean algorithm.
+	a, b = f.Normalize(a), f.Normalize(b)
+	for !f.IsIdenticalZero(b) {
+		rem, _ := f.ModPolynomial(a, b)
+		a, b = b, rem
+	}
+	// Make the result monic; a is non-zero here.
+	return f.MakeMonic(a)
+}
+
+// Reduce returns the residue of p modulo the irreducible polynomial of the
+// field f, treating p as a polynomial over GF(2). Only the lowest bit of each
+// coefficient is used, so coefficients should be zero or one. For example,
+// Reduce returns Num(0x1d) for x⁸ in NewField(0x11d, 0x2).
+func (f *Field) Reduce(p Polynomial) Num {
+	// The code below implements Horner's rule, where multiplying by x is a
+	// shift followed by at most one subtraction of the polynomial.
+	high := Num(1) << f.m
+	r := f.Zero()
+	for i := len(p) - 1; i >= 0; i-- {
+		r = r << 1
+		if r&high != 0 {
+			r = r ^ Num(f.poly)
+		}
+		r = r ^ (p[i] & 0x01)
+	}
+	return r
+}
+
+// MinimalPolynomial returns the minimal polynomial of x over GF(2): the
+// product of (y-c) over the conjugates c of x. All its coefficients are
+// zero or one, and its degree equals the number of conjugates of x.
+func (f *Field) MinimalPolynomial(x Num) Polynomial {
+	return f.PolynomialFromRoots(f.Conjugates(x))
+}
+
+// PolynomialFromRoots returns the monic polynomial ∏(x-r) over all r in
+// roots, whose degree is len(roots). A root listed k times is a root of
+// multiplicity k. The result is the constant one if roots is empty.
+func (f *Field) PolynomialFromRoots(roots []Num) Polynomial {
+	p := Polynomial{f.One()}
+	for _, r := range roots {
+		// Subtraction is addition, so x-r == x+r.
+		p = f.MultiplyPolynomials(p, Polynomial{r, f.One()})
+	}
+	return p
+}
+
+// Interpolate returns the unique polynomial of degree less than len(xs)
+// whose value at xs[i] is ys[i] for every i, or an error if xs and ys have
+// different lengths, xs contains duplicate values, or a value is not in the
+// field.
+func (f *Field) Interpolate(xs, ys []Num) (Polynomial, error) {
+	if len(xs) != len(ys) {
+		return nil, fmt.Errorf("Interpolate: %d x-values but %d y-values.", len(xs), len(ys))
+	}
+	for i := range xs {
+		if !f.inRange(xs[i]) || !f.inRange(ys[i]) {
+			return nil, fmt.Errorf("Interpolate: %w: point %d is (%v, %v)", ErrOutOfRange, i, xs[i], ys[i])
+		}
+	}
+	// The code below implements Lagrange interpolation: the result is
+	// the sum over i of ys[i]×∏(x-xs[j])/(xs[i]-xs[j]) for all j≠i.
+	result := Polynomial{f.Zero()}
+	for i := range xs {
+		basis := Polynomial{f.One()}
+		denominator := f.One()
+		for j := range xs {
+			if j == i {
+				continue
+			}
+			if xs[i] == xs[j] {
+				return nil, fmt.Errorf("Interpolate: duplicate x-value %v.", xs[i])
+			}
+			basis = f.MultiplyPolynomials(basis, Polynomial{xs[j], f.One()})
+			denominator = f.Mul(denominator, f.Add(xs[i], xs[j]))
+		}
+		scale, _ := f.Div(ys[i], denominator)
+		for k := range basis {
+			basis[k] = f.Mul(basis[k], scale)
+		}
+		result = f.AddPolynomials(result, basis)
+	}
+	return f.Normalize(result), nil
 }
 
 // ToString returns a human-readable string representation of the polynomial.
-// Each coefficient is expressed in terms of the field generator.
+// Each coefficient is expressed in terms of the field generator α, and the
+// variable is written x.
 func (f *Field) ToString(p Polynomial) string {
-	var s string
-	for power := len(p) - 1; power >= 0; power-- {
-		n := p[power]
-		if n == f.Zero() {
-			continue
-		}
+	return f.ToStringWith(p, "α", "x")
+}
+
+// ToStringWith is like ToString but writes the field generator as genSymbol
+// and the variable as varSymbol; for example, ToStringWith(p, "β", "y")
+// gives y^2 + β^25 y + β.
+func (f *Field) ToStringWith(p Polynomial, genSymbol, varSymbol string) string {
+	return formatPolynomial(p, varSymbol, f.generatorPower(genSymbol, asciiExponent), asciiExponent, false)
+}
+
+// ToStringOrder is like ToString but writes the terms in order of increasing
+// degree if ascending is true, as some references do.
+func (f *Field) ToStringOrder(p Polynomial, ascending bool) string {
+	return formatPolynomial(p, "x", f.generatorPower("α", asciiExponent), asciiExponent, ascending)
+}
+
+// ToStringPretty is like ToString but writes exponents as superscripts, in
+// the same way as Irreducible.String: x⁵ + α x⁴ + α¹²⁹ x³ + x + α¹⁷⁵.
+func (f *Field) ToStringPretty(p Polynomial) string {
+	return formatPolynomial(p, "x", f.generatorPower("α", superscript), superscript, false)
+}
+
+// generatorPower returns a function that writes a non-zero coefficient as
+// a power of the field generator, written as symbol, using exponent to
+// write the exponent.
+func (f *Field) generatorPower(symbol string, exponent func(int) string) func(Num) string {
+	return func(n Num) string {
 		log, _ := f.Log(n)
-		coeff := fmt.Sprintf("α^%d", log)
 		switch log {
 		case 0:
-			coeff = "1"
-		case 1:
-			coeff = "α"
-		}
-		monomial := fmt.Sprintf("x^%d", power)
-		switch power {
-		case 0:
-			monomial = "1"
+			return "1"
 		case 1:
-			monomial = "x"
+			return symbol
 		}
-		term := coeff + " " + monomial
-		if log == 0 {
-			term = monomial
-		} else {
-			if power == 0 {
-				term = coeff
-			}
-		}
-		if s == "" {
-			s = term
-		} else {
-			s = s + " + " + term
-		}
-	}
-	if s == "" {
-		s = "0"
+		return symbol + exponent(log)
 	}
-	return s
 }
 
-func (p Polynomial) String() string {
+// formatPolynomial returns a string representation of p in the given variable
+// with terms in order of decreasing degree, or increasing degree if ascending
+// is true. The function coeff writes non-zero coefficients and exponent
+// writes exponents of the variable above one; coefficients written as "1"
+// are left out except in the constant term.
+func formatPolynomial(p Polynomial, variable string, coeff func(Num) string, exponent func(int) string, ascending bool) string {
 	var s string
-	for power := len(p) - 1; power >= 0; power-- {
+	for i := range p {
+		power := len(p) - 1 - i
+		if ascending {
+			power = i
+		}
 		n := p[power]
 		if n == 0 {
 			continue
 		}
-		coeff := fmt.Sprintf("%b", n)
-		monomial := fmt.Sprintf("x^%d", power)
+		c := coeff(n)
+		monomial := variable + exponent(power)
 		switch power {
 		case 0:
 			monomial = "1"
 		case 1:
-			monomial = "x"
+			monomial = variable
 		}
-		term := coeff + " " + monomial
-		if coeff == "1" {
+		term := c + " " + monomial
+		if c == "1" {
 			term = monomial
 		} else {
 			if power == 0 {
-				term = coeff
+				term = c
 			}
 		}
 		if s == "" {
@@ -199,3 +671,119 @@ func (p Polynomial) String() string {
 	}
 	return s
 }
+
+// asciiExponent writes n as ^n.
+func asciiExponent(n int) string {
+	return fmt.Sprintf("^%d", n)
+}
+
+// BytesToPolynomial returns the polynomial whose coefficient for x^i is b[i].
+func BytesToPolynomial(b []byte) Polynomial {
+	p := make(Polynomial, len(b))
+	for i, n := range b {
+		p[i] = NumFromByte(n)
+	}
+	return p
+}
+
+// PolynomialToBytes returns the coefficients of p as bytes, with the
+// coefficient for x^i at index i, or an error if any coefficient does not
+// fit in a byte.
+func PolynomialToBytes(p Polynomial) ([]byte, error) {
+	b := make([]byte, len(p))
+	for i, n := range p {
+		c, err := n.Byte()
+		if err != nil {
+			return nil, fmt.Errorf("PolynomialToBytes: coefficient of x^%d: %v", i, err)
+		}
+		b[i] = c
+	}
+	return b, nil
+}
+
+// Clone returns a copy of p that does not share its backing array with p,
+// or nil if p is nil.
+func (p Polynomial) Clone() Polynomial {
+	if p == nil {
+		return nil
+	}
+	clone := make(Polynomial, len(p))
+	copy(clone, p)
+	return clone
+}
+
+func (p Polynomial) String() string {
+	binary := func(n Num) string { return fmt.Sprintf("%b", n) }
+	return formatPolynomial(p, "x", binary, asciiExponent, false)
+}
+
+// maxParsedPower is the largest power of x accepted by ParsePolynomial. It
+// equals the order of the largest supported field, GF(2¹⁶), and keeps a
+// malicious exponent from causing a huge allocation.
+const maxParsedPower = 1 << 16
+
+// ParsePolynomial parses the string representation of a polynomial as
+// returned by String, e.g. “x^5 + 10 x^4 + 10111 x^3 + x + 11111111”. Powers
+// of x above 2¹⁶ are rejected.
+func ParsePolynomial(s string) (Polynomial, error) {
+	if s == "0" {
+		return Polynomial{0}, nil
+	}
+	coefficients := make(map[int]Num)
+	maxPower := -1
+	for _, term := range strings.Split(s, " + ") {
+		coeff, power, err := parseTerm(term)
+		if err != nil {
+			return nil, fmt.Errorf("Cannot parse %q as a polynomial: %v", s, err)
+		}
+		if _, ok := coefficients[power]; ok {
+			return nil, fmt.Errorf("Cannot parse %q as a polynomial: repeated power %d.", s, power)
+		}
+		coefficients[power] = coeff
+		if power > maxPower {
+			maxPower = power
+		}
+	}
+	p := make(Polynomial, maxPower+1)
+	for power, coeff := range coefficients {
+		p[power] = coeff
+	}
+	return p, nil
+}
+
+// parseTerm parses a single term such as “10 x^4”, “x”, or “10111” and
+// returns its coefficient and power.
+func parseTerm(term string) (Num, int, error) {
+	fields := strings.Split(term, " ")
+	var coeff, monomial string
+	switch {
+	case len(fields) == 2:
+		coeff, monomial = fields[0], fields[1]
+	case len(fields) == 1 && strings.HasPrefix(term, "x"):
+		coeff, monomial = "1", term
+	case len(fields) == 1:
+		coeff, monomial = term, "1"
+	default:
+		return 0, 0, fmt.Errorf("malformed term %q.", term)
+	}
+	n, err := ParseNum(coeff)
+	if err != nil {
+		return 0, 0, fmt.Errorf("malformed coefficient in term %q.", term)
+	}
+	switch {
+	case monomial == "1" && len(fields) == 1:
+		return n, 0, nil
+	case monomial == "x":
+		return n, 1, nil
+	case strings.HasPrefix(monomial, "x^"):
+		power, err := strconv.Atoi(monomial[2:])
+		if err != nil || power < 0 {
+			return 0, 0, fmt.Errorf("malformed power in term %q.", term)
+		}
+		if power > maxParsedPower {
+			return 0, 0, fmt.Errorf("power in term %q exceeds %d.", term, maxParsedPower)
+		}
+		return n, power, nil
+	}
+	return 0, 0, fmt.Errorf("malformed monomial in term %q.", term)
+}
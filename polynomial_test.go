@@ -14,7 +14,12 @@
 
 package gf256
 
+import "errors"
 import "fmt"
+import "math/rand"
+import "sort"
+import "strings"
+import "testing"
 
 func ExamplePolynomial() {
 	f, _ := NewField(0x11d, 0x2)
@@ -26,6 +31,45 @@ func ExamplePolynomial() {
 	// x^5 + α x^4 + α^129 x^3 + x + α^175
 }
 
+func ExampleField_ToStringOrder() {
+	f, _ := NewField(0x11d, 0x2)
+	p := Polynomial{0xff, 0x01, 0x00, 0x17, 0x02, 0x01}
+	fmt.Println(f.ToStringOrder(p, false))
+	fmt.Println(f.ToStringOrder(p, true))
+	// Output:
+	// x^5 + α x^4 + α^129 x^3 + x + α^175
+	// α^175 + x + α^129 x^3 + α x^4 + x^5
+}
+
+func ExampleField_ToStringPretty() {
+	f, _ := NewField(0x11d, 0x2)
+	p := Polynomial{0xff, 0x01, 0x00, 0x17, 0x02, 0x01}
+	fmt.Println(f.ToStringPretty(p))
+	fmt.Println(f.ToStringPretty(f.RSGeneratorPoly(7)))
+	// Output:
+	// x⁵ + α x⁴ + α¹²⁹ x³ + x + α¹⁷⁵
+	// x⁷ + α⁸⁷ x⁶ + α²²⁹ x⁵ + α¹⁴⁶ x⁴ + α¹⁴⁹ x³ + α²³⁸ x² + α¹⁰² x + α²¹
+}
+
+func ExampleField_Degree() {
+	f, _ := NewField(0x11d, 0x2)
+	fmt.Println(f.Degree(Polynomial{0xff, 0x01, 0x00, 0x17, 0x02, 0x01}))
+	fmt.Println(f.Degree(Polynomial{0x17, 0x01, 0x00, 0x00}))
+	fmt.Println(f.Degree(Polynomial{0x00, 0x00}))
+	// Output:
+	// 5
+	// 1
+	// -1
+}
+
+func ExampleParsePolynomial() {
+	f, _ := NewField(0x11d, 0x2)
+	p, _ := ParsePolynomial("x^5 + 10 x^4 + 10111 x^3 + x + 11111111")
+	fmt.Println(f.ToString(p))
+	// Output:
+	// x^5 + α x^4 + α^129 x^3 + x + α^175
+}
+
 func ExampleComputeWithPolynomials() {
 	f, _ := NewField(0x11d, 0x2)
 	p1 := Polynomial{0xff, 0x01, 0x00, 0x17, 0x02, 0x01}
@@ -49,6 +93,131 @@ func ExampleComputeWithPolynomials() {
 	// x^7 + 10 x^6 + 10110 x^5 + 10 x^4 + 10110 x^3 + 11111111 x^2 + x + 11111111
 }
 
+func ExampleField_GCD() {
+	f, _ := NewField(0x11d, 0x2)
+	p1 := f.MultiplyPolynomials(Polynomial{0x02, 0x01}, Polynomial{0x03, 0x01})
+	p2 := f.MultiplyPolynomials(Polynomial{0x02, 0x01}, Polynomial{0x04, 0x01})
+	gcd, _ := f.GCD(p1, p2)
+	fmt.Println(gcd)
+	// Output:
+	// x + 10
+}
+
+func ExampleField_PowPolynomial() {
+	f, _ := NewField(0x11d, 0x2)
+	p := Polynomial{0x01, 0x01}
+	fmt.Println(f.PowPolynomial(p, 3))
+	fmt.Println(f.PowPolynomial(p, 0))
+	// Output:
+	// x^3 + x^2 + x + 1
+	// 1
+}
+
+func ExampleField_PowModPolynomial() {
+	f, _ := NewField(0x11d, 0x2)
+	// x^256 mod (x^8+x^4+x^3+x^2+1) == x, since x^8+x^4+x^3+x^2+1 is irreducible.
+	x := Polynomial{0x00, 0x01}
+	m := Polynomial{0x01, 0x00, 0x01, 0x01, 0x01, 0x00, 0x00, 0x00, 0x01}
+	p, _ := f.PowModPolynomial(x, 256, m)
+	fmt.Println(p)
+	// Output:
+	// x
+}
+
+func ExampleField_ScalePolynomial() {
+	f, _ := NewField(0x11d, 0x2)
+	p := Polynomial{0x01, 0x02, 0x03}
+	fmt.Println(f.ScalePolynomial(0x02, p))
+	// Output:
+	// 110 x^2 + 100 x + 10
+}
+
+func ExampleField_MakeMonic() {
+	f, _ := NewField(0x11d, 0x2)
+	p := Polynomial{0x02, 0x04, 0x02, 0x00}
+	monic, _ := f.MakeMonic(p)
+	fmt.Println(monic)
+	// Output:
+	// x^2 + 10 x + 1
+}
+
+func ExampleField_ShiftPolynomial() {
+	f, _ := NewField(0x11d, 0x2)
+	p := Polynomial{0x03, 0x02, 0x01}
+	fmt.Println(f.ShiftPolynomial(p, 2))
+	fmt.Println(f.ShiftPolynomial(p, -1))
+	// Output:
+	// x^4 + 10 x^3 + 11 x^2
+	// x + 10
+}
+
+func ExampleField_Derivative() {
+	f, _ := NewField(0x11d, 0x2)
+	p := Polynomial{0xff, 0x01, 0x00, 0x17, 0x02, 0x01}
+	fmt.Println(f.Derivative(p))
+	// Output:
+	// x^4 + 10111 x^2 + 1
+}
+
+func ExampleField_EvaluateAll() {
+	f, _ := NewField(0x11d, 0x2)
+	p := Polynomial{0x01, 0x00, 0x01}
+	fmt.Println(f.EvaluateAll(p, []Num{0x00, 0x01, 0x02, 0x03}))
+	// Output:
+	// [1 0 101 100]
+}
+
+func ExampleField_Roots() {
+	f, _ := NewField(0x11d, 0x2)
+	p := f.MultiplyPolynomials(Polynomial{0x03, 0x01}, Polynomial{0x11, 0x01})
+	fmt.Println(f.Roots(p))
+	// Output:
+	// [11 10001]
+}
+
+func ExampleField_Reduce() {
+	f, _ := NewField(0x11d, 0x2)
+	x8 := Polynomial{0, 0, 0, 0, 0, 0, 0, 0, 1}
+	fmt.Println(f.Reduce(x8))
+	// Output:
+	// 11101
+}
+
+func ExampleField_CountRoots() {
+	f, _ := NewField(0x11d, 0x2)
+	p := f.MultiplyPolynomials(Polynomial{0x03, 0x01}, Polynomial{0x11, 0x01})
+	fmt.Println(f.CountRoots(p))
+	fmt.Println(f.CountRoots(Polynomial{0x20, 0x01, 0x01}))
+	// Output:
+	// 2
+	// 0
+}
+
+func ExampleField_MinimalPolynomial() {
+	f, _ := NewField(0x11d, 0x2)
+	fmt.Println(f.MinimalPolynomial(f.Generator()))
+	// Output:
+	// x^8 + x^4 + x^3 + x^2 + 1
+}
+
+func ExampleField_Interpolate() {
+	f, _ := NewField(0x11d, 0x2)
+	xs := []Num{0x01, 0x02, 0x03}
+	ys := []Num{0x00, 0x05, 0x04}
+	p, _ := f.Interpolate(xs, ys)
+	fmt.Println(p)
+	// Output:
+	// x^2 + 1
+}
+
+func ExampleField_ModPolynomial() {
+	f, _ := NewField(0x11d, 0x2)
+	rem, _ := f.ModPolynomial(Polynomial{0x01, 0x00, 0x01}, Polynomial{0x02, 0x01})
+	fmt.Println(rem)
+	// Output:
+	// 101
+}
+
 func ExampleLongDivision() {
 	f, _ := NewField(0x11d, 0x2)
 	nominator := Polynomial{0xff, 0x01, 0x00, 0x17, 0x02, 0x01}
@@ -104,5 +273,1336 @@ func ExampleLongDivisionZeroDenominator() {
 	_, _, err := f.DividePolynomials(nominator, denominator)
 	fmt.Println(err)
 	// Output:
-	// Division by zero polynomial: 10 x^2 + x + 10111.
+	// gf256: division by zero polynomial: 10 x^2 + x + 10111
+}
+
+func TestDivisionByZeroPolynomial(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	_, _, err = f.DividePolynomials(Polynomial{0x01, 0x01}, Polynomial{0x00})
+	if !errors.Is(err, ErrDivideByZeroPolynomial) {
+		t.Errorf("Expected ErrDivideByZeroPolynomial, got %v.", err)
+	}
+}
+
+func TestModPolynomial(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	testData := []Polynomial{
+		nil,
+		{0x00},
+		{0x01},
+		{0x53},
+		{0x02, 0x01},
+		{0x02, 0x01, 0x00, 0x00},
+		{0x53, 0x00, 0x01},
+		{0xff, 0x01, 0x00, 0x17, 0x02},
+		{0x8e, 0x01, 0x00, 0x17, 0x02, 0x01, 0xca, 0x00, 0x03},
+	}
+	for _, nom := range testData {
+		for _, den := range testData {
+			_, expected, divErr := f.DividePolynomials(nom, den)
+			actual, modErr := f.ModPolynomial(nom, den)
+			if !errors.Is(modErr, ErrDivideByZeroPolynomial) && f.IsIdenticalZero(den) {
+				t.Errorf("(%v) mod (%v): expected ErrDivideByZeroPolynomial, got %v.", nom, den, modErr)
+				continue
+			}
+			if (divErr == nil) != (modErr == nil) {
+				t.Errorf("(%v) mod (%v): DividePolynomials error %v, ModPolynomial error %v.", nom, den, divErr, modErr)
+				continue
+			}
+			if expected.String() != actual.String() || len(expected) != len(actual) {
+				t.Errorf("(%v) mod (%v): expected %v, actual %v.", nom, den, expected, actual)
+			}
+		}
+	}
+}
+
+func TestNewEvaluator(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	long := make(Polynomial, 600)
+	for i := range long {
+		long[i] = Num(i * 7 % 256)
+	}
+	testData := []Polynomial{
+		nil,
+		{0x00},
+		{0x53},
+		{0x02, 0x01},
+		{0xff, 0x01, 0x00, 0x17, 0x02},
+		{0x8e, 0x01, 0x00, 0x17, 0x02, 0x01, 0xca, 0x00, 0x03},
+		long,
+	}
+	// 0x01 has order 1 and f.Exp(85) has order 3, so the precomputed powers
+	// wrap around for long polynomials.
+	for _, x := range []Num{0x00, 0x01, 0x02, f.Exp(85), 0x53, 0xff} {
+		evaluate := f.NewEvaluator(x)
+		for _, p := range testData {
+			if expected, actual := f.EvaluatePolynomial(p, x), evaluate(p); expected != actual {
+				t.Errorf("NewEvaluator(%v)(%v): expected %v, actual %v.", x, p, expected, actual)
+			}
+		}
+	}
+}
+
+func TestNewEvaluatorOutOfRange(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	for _, x := range []Num{256, 1000} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Expected NewEvaluator(%v) to panic.", x)
+				} else if msg := fmt.Sprint(r); msg != fmt.Sprintf("NewEvaluator: %v: gf256: number out of range.", x) {
+					t.Errorf("Unexpected panic message: %v", msg)
+				}
+			}()
+			f.NewEvaluator(x)
+		}()
+	}
+}
+
+func benchmarkPolynomial() Polynomial {
+	p := make(Polynomial, 255)
+	for i := range p {
+		p[i] = Num(i)
+	}
+	return p
+}
+
+func BenchmarkEvaluatePolynomial(b *testing.B) {
+	f, _ := NewField(0x11d, 0x02)
+	p := benchmarkPolynomial()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		f.EvaluatePolynomial(p, 0x53)
+	}
+}
+
+func BenchmarkEvaluator(b *testing.B) {
+	f, _ := NewField(0x11d, 0x02)
+	p := benchmarkPolynomial()
+	evaluate := f.NewEvaluator(0x53)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		evaluate(p)
+	}
+}
+
+func TestQuotientPolynomial(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	testData := []Polynomial{
+		nil,
+		{0x00},
+		{0x01},
+		{0x53},
+		{0x02, 0x01},
+		{0x53, 0x00, 0x01},
+		{0xff, 0x01, 0x00, 0x17, 0x02},
+		{0x8e, 0x01, 0x00, 0x17, 0x02, 0x01, 0xca, 0x00, 0x03},
+	}
+	for _, nom := range testData {
+		for _, den := range testData {
+			expected, _, divErr := f.DividePolynomials(nom, den)
+			actual, err := f.QuotientPolynomial(nom, den)
+			if (divErr == nil) != (err == nil) {
+				t.Errorf("(%v) / (%v): DividePolynomials error %v, QuotientPolynomial error %v.", nom, den, divErr, err)
+				continue
+			}
+			if f.IsIdenticalZero(den) && !errors.Is(err, ErrDivideByZeroPolynomial) {
+				t.Errorf("(%v) / (%v): expected ErrDivideByZeroPolynomial, got %v.", nom, den, err)
+			}
+			if expected.String() != actual.String() || len(expected) != len(actual) {
+				t.Errorf("(%v) / (%v): expected %v, actual %v.", nom, den, expected, actual)
+			}
+		}
+	}
+}
+
+func TestEvalSynthetic(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	testData := []Polynomial{
+		nil,
+		{0x00},
+		{0x53},
+		{0x02, 0x01},
+		{0x53, 0x00, 0x01, 0x00},
+		{0xff, 0x01, 0x00, 0x17, 0x02},
+		{0x8e, 0x01, 0x00, 0x17, 0x02, 0x01, 0xca, 0x00, 0x03},
+	}
+	for _, p := range testData {
+		for _, r := range []Num{0x00, 0x01, 0x02, 0x53, 0xff} {
+			value, quotient := f.EvalSynthetic(p, r)
+			if expected := f.EvaluatePolynomial(p, r); value != expected {
+				t.Errorf("EvalSynthetic(%v, %v): expected value %v, actual %v.", p, r, expected, value)
+			}
+			product := f.MultiplyPolynomials(quotient, Polynomial{r, f.One()})
+			if actual := f.AddPolynomials(product, Polynomial{value}); actual.String() != p.String() {
+				t.Errorf("EvalSynthetic(%v, %v): quotient %v and value %v give %v.", p, r, quotient, value, actual)
+			}
+		}
+	}
+}
+
+func TestShift(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	testData := []struct {
+		p        Polynomial
+		a        Num
+		expected Polynomial
+	}{
+		{nil, 0x17, Polynomial{0x00}},
+		{Polynomial{0x53}, 0x17, Polynomial{0x53}},
+		{Polynomial{0x00, 0x00, 0x00, 0x01}, 0x01, Polynomial{0x01, 0x01, 0x01, 0x01}}, // (x+1)³
+		{Polynomial{0x00, 0x01, 0x01}, 0x02, Polynomial{0x06, 0x01, 0x01}},             // (x+2)² + (x+2)
+		{Polynomial{0xff, 0x01, 0x00, 0x17}, 0x00, Polynomial{0xff, 0x01, 0x00, 0x17}},
+	}
+	for _, data := range testData {
+		if actual := f.Shift(data.p, data.a); actual.String() != data.expected.String() {
+			t.Errorf("Shift(%v, %v): expected %v, actual %v.", data.p, data.a, data.expected, actual)
+		}
+	}
+	for _, p := range []Polynomial{
+		{0xff, 0x01, 0x00, 0x17, 0x02},
+		{0x8e, 0x01, 0x00, 0x17, 0x02, 0x01, 0xca, 0x00, 0x03},
+	} {
+		for _, a := range []Num{0x01, 0x02, 0x53, 0xff} {
+			shifted := f.Shift(p, a)
+			for _, x := range []Num{0x00, 0x01, 0x1d, 0x80} {
+				if expected, actual := f.EvaluatePolynomial(p, f.Add(x, a)), f.EvaluatePolynomial(shifted, x); expected != actual {
+					t.Errorf("Shift(%v, %v) at %v: expected %v, actual %v.", p, a, x, expected, actual)
+				}
+			}
+			// Shifting by a twice shifts by a+a == 0.
+			if actual := f.Shift(shifted, a); actual.String() != p.String() {
+				t.Errorf("Shift(Shift(%v, %v), %v): expected %v, actual %v.", p, a, a, p, actual)
+			}
+		}
+	}
+}
+
+func TestDeflateRoot(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	testData := [][]Num{
+		{0x01},
+		{0x00, 0x02},
+		{0x03, 0x05, 0x07, 0x53},
+		{0x80, 0x1d, 0xff, 0x00, 0xca, 0x8e},
+	}
+	for _, roots := range testData {
+		p := Polynomial{0x17}
+		for _, r := range roots {
+			p = f.MultiplyPolynomials(p, Polynomial{r, f.One()})
+		}
+		original := p
+		for i, r := range roots {
+			p, err = f.DeflateRoot(p, r)
+			if err != nil {
+				t.Errorf("DeflateRoot(%v, %v): unexpected error %v.", original, r, err)
+				break
+			}
+			if f.Degree(p) != len(roots)-i-1 {
+				t.Errorf("DeflateRoot(%v, %v): expected degree %d, actual %v.", original, r, len(roots)-i-1, p)
+			}
+		}
+		if err == nil && (len(p) != 1 || p[0] != 0x17) {
+			t.Errorf("Deflating all roots of %v: expected 0x17, actual %v.", original, p)
+		}
+	}
+	p := Polynomial{0x03, 0x02, 0x01} // (x+1)(x+3)
+	if _, err := f.DeflateRoot(p, 0x02); !errors.Is(err, ErrNotARoot) {
+		t.Errorf("DeflateRoot(%v, 0x02): expected ErrNotARoot, got %v.", p, err)
+	}
+	if _, err := f.DeflateRoot(Polynomial{0x05}, 0x00); !errors.Is(err, ErrNotARoot) {
+		t.Errorf("DeflateRoot({0x05}, 0x00): expected ErrNotARoot, got %v.", err)
+	}
+	if _, err := f.DeflateRoot(nil, 0x01); !errors.Is(err, ErrZeroPolynomial) {
+		t.Errorf("DeflateRoot(nil, 0x01): expected ErrZeroPolynomial, got %v.", err)
+	}
+}
+
+func TestDivides(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	generator := f.RSGeneratorPoly(10)
+	codeword := f.RSEncode(Polynomial{0x20, 0x5b, 0x0b, 0x78, 0xd1, 0x72}, 10)
+	if ok, err := f.Divides(generator, codeword); !ok || err != nil {
+		t.Errorf("Generator should divide codeword %v: %v, %v.", codeword, ok, err)
+	}
+	for i := range codeword {
+		corrupted := codeword.Clone()
+		corrupted[i] ^= 0x01
+		if ok, err := f.Divides(generator, corrupted); ok || err != nil {
+			t.Errorf("Generator should not divide corrupted codeword %v: %v, %v.", corrupted, ok, err)
+		}
+	}
+	testData := []struct {
+		den, nom Polynomial
+		expected bool
+	}{
+		{Polynomial{0x53}, Polynomial{0x01, 0x02, 0x03}, true},
+		{Polynomial{0x01, 0x01}, Polynomial{0x01, 0x00, 0x01}, true},
+		{Polynomial{0x01, 0x01}, Polynomial{0x01, 0x01, 0x01}, false},
+		{Polynomial{0x01, 0x00, 0x01}, Polynomial{0x01, 0x01}, false},
+		{Polynomial{0x01, 0x00, 0x01}, nil, true},
+	}
+	for _, data := range testData {
+		if ok, err := f.Divides(data.den, data.nom); ok != data.expected || err != nil {
+			t.Errorf("Divides(%v, %v): expected %v, got %v, %v.", data.den, data.nom, data.expected, ok, err)
+		}
+	}
+	if _, err := f.Divides(Polynomial{0x00}, codeword); !errors.Is(err, ErrDivideByZeroPolynomial) {
+		t.Errorf("Expected ErrDivideByZeroPolynomial, got %v.", err)
+	}
+}
+
+func TestDegree(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	testData := []struct {
+		p              Polynomial
+		expectedDegree int
+	}{
+		{Polynomial{}, -1},
+		{Polynomial{0x00}, -1},
+		{Polynomial{0x00, 0x00, 0x00}, -1},
+		{Polynomial{0x01}, 0},
+		{Polynomial{0x17, 0x00}, 0},
+		{Polynomial{0x00, 0x01}, 1},
+		{Polynomial{0x01, 0x00, 0x04, 0x00, 0x00}, 2},
+		{Polynomial{0xff, 0x01, 0x00, 0x17, 0x02, 0x01}, 5},
+	}
+	for _, data := range testData {
+		if degree := f.Degree(data.p); degree != data.expectedDegree {
+			t.Errorf("Degree(%v): expected %d, got %d.", data.p, data.expectedDegree, degree)
+		}
+	}
+}
+
+func TestNormalizeDoesNotAlias(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	p := Polynomial{0x17, 0x01, 0x02, 0x00, 0x00}
+	n := f.Normalize(p)
+	if n.String() != "10 x^2 + x + 10111" {
+		t.Errorf("Unexpected normalized polynomial: %v.", n)
+	}
+	n[0] = 0xff
+	n = append(n, 0x01)
+	if p.String() != "10 x^2 + x + 10111" {
+		t.Errorf("Modifying normalized polynomial changed input to %v.", p)
+	}
+}
+
+func TestEmptyPolynomials(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	one := Polynomial{0x01}
+	p := Polynomial{0xff, 0x01, 0x17}
+	for _, e := range []Polynomial{nil, {}} {
+		if !f.IsIdenticalZero(e) {
+			t.Errorf("IsIdenticalZero(%#v): expected true.", e)
+		}
+		if n := f.Normalize(e); len(n) != 1 || n[0] != f.Zero() {
+			t.Errorf("Normalize(%#v): expected [0], actual %#v.", e, n)
+		}
+		if d := f.Degree(e); d != -1 {
+			t.Errorf("Degree(%#v): expected -1, actual %d.", e, d)
+		}
+		if y := f.EvaluatePolynomial(e, 0x53); y != f.Zero() {
+			t.Errorf("EvaluatePolynomial(%#v, 53): expected 0, actual %v.", e, y)
+		}
+		if ys := f.EvaluateAll(e, []Num{0x00, 0x53}); ys[0] != f.Zero() || ys[1] != f.Zero() {
+			t.Errorf("EvaluateAll(%#v): expected zeros, actual %v.", e, ys)
+		}
+		if roots := f.Roots(e); len(roots) != 256 {
+			t.Errorf("Roots(%#v): expected 256 roots, actual %d.", e, len(roots))
+		}
+		if sum := f.AddPolynomials(e, p); sum.String() != p.String() {
+			t.Errorf("AddPolynomials(%#v, %v): expected %v, actual %v.", e, p, p, sum)
+		}
+		if q := f.PowPolynomial(e, 0); q.String() != one.String() {
+			t.Errorf("PowPolynomial(%#v, 0): expected 1, actual %v.", e, q)
+		}
+		if q := f.PowPolynomial(e, 3); !f.IsIdenticalZero(q) {
+			t.Errorf("PowPolynomial(%#v, 3): expected 0, actual %v.", e, q)
+		}
+		if q, err := f.PowModPolynomial(e, 3, p); err != nil || !f.IsIdenticalZero(q) {
+			t.Errorf("PowModPolynomial(%#v, 3, %v): expected 0, actual %v, %v.", e, p, q, err)
+		}
+		if _, err := f.PowModPolynomial(p, 3, e); !errors.Is(err, ErrDivideByZeroPolynomial) {
+			t.Errorf("PowModPolynomial(%v, 3, %#v): expected ErrDivideByZeroPolynomial, got %v.", p, e, err)
+		}
+		if q := f.ScalePolynomial(0x53, e); !f.IsIdenticalZero(q) {
+			t.Errorf("ScalePolynomial(53, %#v): expected 0, actual %v.", e, q)
+		}
+		if _, err := f.MakeMonic(e); !errors.Is(err, ErrZeroPolynomial) {
+			t.Errorf("MakeMonic(%#v): expected ErrZeroPolynomial, got %v.", e, err)
+		}
+		if q := f.ShiftPolynomial(e, 2); !f.IsIdenticalZero(q) {
+			t.Errorf("ShiftPolynomial(%#v, 2): expected 0, actual %v.", e, q)
+		}
+		if q := f.ShiftPolynomial(e, -2); !f.IsIdenticalZero(q) {
+			t.Errorf("ShiftPolynomial(%#v, -2): expected 0, actual %v.", e, q)
+		}
+		if q := f.Derivative(e); !f.IsIdenticalZero(q) {
+			t.Errorf("Derivative(%#v): expected 0, actual %v.", e, q)
+		}
+		quot, rem, err := f.DividePolynomials(e, p)
+		if err != nil || !f.IsIdenticalZero(quot) || !f.IsIdenticalZero(rem) || len(rem) != 1 {
+			t.Errorf("DividePolynomials(%#v, %v): expected 0, 0, actual %v, %#v, %v.", e, p, quot, rem, err)
+		}
+		if _, _, err := f.DividePolynomials(p, e); !errors.Is(err, ErrDivideByZeroPolynomial) {
+			t.Errorf("DividePolynomials(%v, %#v): expected ErrDivideByZeroPolynomial, got %v.", p, e, err)
+		}
+		if gcd, err := f.GCD(e, p); err != nil || gcd.String() != "x^2 + 1100110 x + 10011111" {
+			t.Errorf("GCD(%#v, %v): unexpected result %v, %v.", e, p, gcd, err)
+		}
+		if _, err := f.GCD(e, e); !errors.Is(err, ErrZeroPolynomial) {
+			t.Errorf("GCD(%#v, %#v): expected ErrZeroPolynomial, got %v.", e, e, err)
+		}
+		if s := f.ToString(e); s != "0" {
+			t.Errorf("ToString(%#v): expected 0, actual %q.", e, s)
+		}
+		if s := e.String(); s != "0" {
+			t.Errorf("String(%#v): expected 0, actual %q.", e, s)
+		}
+	}
+}
+
+func TestBytesToPolynomial(t *testing.T) {
+	testData := [][]byte{
+		nil,
+		{},
+		{0x00},
+		{0x17, 0x01, 0x02, 0x00},
+		{0xff, 0x00, 0x80, 0x01, 0xca},
+	}
+	for _, b := range testData {
+		p := BytesToPolynomial(b)
+		if len(p) != len(b) {
+			t.Errorf("BytesToPolynomial(%v): unexpected length %d.", b, len(p))
+			continue
+		}
+		for i, n := range b {
+			if p[i] != Num(n) {
+				t.Errorf("BytesToPolynomial(%v): coefficient of x^%d is %v.", b, i, p[i])
+			}
+		}
+		roundTrip, err := PolynomialToBytes(p)
+		if err != nil {
+			t.Errorf("PolynomialToBytes(%v): unexpected error: %v", p, err)
+		}
+		if string(roundTrip) != string(b) {
+			t.Errorf("Round trip of %v returned %v.", b, roundTrip)
+		}
+	}
+	if _, err := PolynomialToBytes(Polynomial{0x01, 0x100}); err == nil {
+		t.Errorf("Expected error return value from out-of-range coefficient.")
+	}
+}
+
+func TestToStringOrder(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	testData := []Polynomial{
+		nil,
+		{0x00},
+		{0x53},
+		{0x02, 0x01},
+		{0x00, 0x80, 0x00, 0x01, 0x00},
+		{0xff, 0x01, 0x00, 0x17, 0x02, 0x01},
+	}
+	for _, p := range testData {
+		descending := f.ToStringOrder(p, false)
+		if descending != f.ToString(p) {
+			t.Errorf("ToStringOrder(%v, false): expected %q, got %q.", p, f.ToString(p), descending)
+		}
+		// The ascending form lists the same terms in reverse order.
+		terms := strings.Split(descending, " + ")
+		for i, j := 0, len(terms)-1; i < j; i, j = i+1, j-1 {
+			terms[i], terms[j] = terms[j], terms[i]
+		}
+		if expected, ascending := strings.Join(terms, " + "), f.ToStringOrder(p, true); ascending != expected {
+			t.Errorf("ToStringOrder(%v, true): expected %q, got %q.", p, expected, ascending)
+		}
+	}
+}
+
+func TestToStringPretty(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	testData := []struct {
+		p        Polynomial
+		expected string
+	}{
+		{nil, "0"},
+		{Polynomial{0x00, 0x00}, "0"},
+		{Polynomial{0x01}, "1"},
+		{Polynomial{0x02}, "α"},
+		{Polynomial{0x00, 0x01}, "x"},
+		{Polynomial{0x03, 0x00, 0x01}, "x² + α²⁵"},
+		{Polynomial{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80}, "α⁷ x¹⁰"},
+	}
+	for _, data := range testData {
+		if s := f.ToStringPretty(data.p); s != data.expected {
+			t.Errorf("ToStringPretty(%v): expected %q, got %q.", data.p, data.expected, s)
+		}
+		// The pretty form differs from ToString only in its exponents.
+		ascii := strings.NewReplacer("⁰", "0", "¹", "1", "²", "2", "³", "3", "⁴", "4", "⁵", "5", "⁶", "6", "⁷", "7", "⁸", "8", "⁹", "9")
+		pretty := f.ToStringPretty(data.p)
+		if plain := f.ToString(data.p); strings.Replace(plain, "^", "", -1) != ascii.Replace(pretty) {
+			t.Errorf("ToStringPretty(%v) == %q does not match ToString == %q.", data.p, pretty, plain)
+		}
+	}
+}
+
+func ExampleField_ToStringWith() {
+	f, _ := NewField(0x11d, 0x2)
+	p := Polynomial{0x02, 0x03, 0x01}
+	fmt.Println(f.ToStringWith(p, "β", "y"))
+	// Output: y^2 + β^25 y + β
+}
+
+func TestToStringWith(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	testData := []struct {
+		p        Polynomial
+		expected string
+	}{
+		{nil, "0"},
+		{Polynomial{0x01}, "1"},
+		{Polynomial{0x02}, "g"},
+		{Polynomial{0x00, 0x01}, "z"},
+		{Polynomial{0x03, 0x00, 0x01}, "z^2 + g^25"},
+		{Polynomial{0xff, 0x01, 0x00, 0x17, 0x02, 0x01}, "z^5 + g z^4 + g^129 z^3 + z + g^175"},
+	}
+	for _, data := range testData {
+		if s := f.ToStringWith(data.p, "g", "z"); s != data.expected {
+			t.Errorf("ToStringWith(%v, g, z): expected %q, got %q.", data.p, data.expected, s)
+		}
+		if s, expected := f.ToString(data.p), f.ToStringWith(data.p, "α", "x"); s != expected {
+			t.Errorf("ToString(%v): expected %q, got %q.", data.p, expected, s)
+		}
+	}
+}
+
+func TestClone(t *testing.T) {
+	p := Polynomial{0x17, 0x01, 0x02, 0x00}
+	clone := p.Clone()
+	if clone.String() != p.String() || len(clone) != len(p) {
+		t.Errorf("Clone(%v): unexpected result %v.", p, clone)
+	}
+	clone[0] = 0xff
+	clone = append(clone, 0x01)
+	if p.String() != "10 x^2 + x + 10111" || len(p) != 4 {
+		t.Errorf("Modifying clone changed original to %v.", p)
+	}
+	if clone := Polynomial(nil).Clone(); clone != nil {
+		t.Errorf("Clone(nil): expected nil, actual %#v.", clone)
+	}
+	if clone := (Polynomial{}).Clone(); clone == nil || len(clone) != 0 {
+		t.Errorf("Clone(Polynomial{}): expected empty polynomial, actual %#v.", clone)
+	}
+}
+
+func TestGCD(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	p := Polynomial{0xff, 0x01, 0x00, 0x17, 0x02, 0x01}
+	q := Polynomial{0x01, 0x00, 0x01}
+	testData := []struct {
+		a, b        Polynomial
+		expectedGCD string
+	}{
+		{Polynomial{0x02, 0x01}, Polynomial{0x03, 0x01}, "1"},            // Coprime.
+		{Polynomial{0x05}, Polynomial{0x03, 0x01}, "1"},                  // Constant.
+		{f.MultiplyPolynomials(p, q), q, "x^2 + 1"},                      // q divides a.
+		{q, f.MultiplyPolynomials(p, q), "x^2 + 1"},                      // q divides b.
+		{Polynomial{0x04, 0x00, 0x02}, Polynomial{0x00}, "x^2 + 10"},     // b is zero.
+		{Polynomial{}, Polynomial{0x04, 0x02, 0x00}, "x + 10"},           // a is zero.
+		{Polynomial{0x06, 0x02}, Polynomial{0x03, 0x01, 0x00}, "x + 11"}, // Equal up to scaling.
+	}
+	for _, data := range testData {
+		gcd, err := f.GCD(data.a, data.b)
+		if err != nil {
+			t.Errorf("GCD(%v, %v): got error %v", data.a, data.b, err)
+		}
+		if gcd.String() != data.expectedGCD {
+			t.Errorf("GCD(%v, %v): expected %v, got %v.", data.a, data.b, data.expectedGCD, gcd)
+		}
+	}
+	if _, err := f.GCD(Polynomial{0x00}, Polynomial{}); !errors.Is(err, ErrZeroPolynomial) {
+		t.Errorf("Expected ErrZeroPolynomial, got %v.", err)
+	}
+}
+
+func TestPowPolynomial(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	testData := []Polynomial{
+		{0x00},
+		{0x01},
+		{0x53},
+		{0x00, 0x01},
+		{0x02, 0x01, 0x00},
+		{0xff, 0x01, 0x00, 0x17},
+	}
+	for _, p := range testData {
+		expected := Polynomial{f.One()}
+		for n := 0; n <= 10; n++ {
+			actual := f.PowPolynomial(p, n)
+			if f.Normalize(expected).String() != actual.String() {
+				t.Errorf("(%v)^%d: expected %v, actual %v.", p, n, expected, actual)
+			}
+			expected = f.MultiplyPolynomials(expected, p)
+		}
+	}
+	// Every element of the field is a root of x^256 - x.
+	p := f.AddPolynomials(f.PowPolynomial(Polynomial{0x00, 0x01}, 256), Polynomial{0x00, 0x01})
+	if d := f.Degree(p); d != 256 {
+		t.Errorf("x^256 - x: expected degree 256, actual %d.", d)
+	}
+	if roots := f.Roots(p); len(roots) != 256 {
+		t.Errorf("x^256 - x: expected 256 roots, actual %d.", len(roots))
+	}
+}
+
+func TestPowPolynomialNegativeExponent(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected PowPolynomial to panic on negative exponent.")
+		}
+	}()
+	f.PowPolynomial(Polynomial{0x00, 0x01}, -1)
+}
+
+func TestPowModPolynomial(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	moduli := []Polynomial{
+		{0x01},
+		{0x02, 0x01},
+		{0x53, 0x00, 0x01},
+		{0xff, 0x01, 0x00, 0x17, 0x02},
+	}
+	bases := []Polynomial{
+		{0x00},
+		{0x01},
+		{0x00, 0x01},
+		{0x8e, 0x01, 0x00, 0x17, 0x02, 0x01},
+	}
+	for _, m := range moduli {
+		for _, p := range bases {
+			for n := 0; n <= 10; n++ {
+				_, expected, _ := f.DividePolynomials(f.PowPolynomial(p, n), m)
+				actual, err := f.PowModPolynomial(p, n, m)
+				if err != nil {
+					t.Errorf("(%v)^%d mod %v: unexpected error: %v", p, n, m, err)
+					continue
+				}
+				if f.Normalize(expected).String() != actual.String() {
+					t.Errorf("(%v)^%d mod %v: expected %v, actual %v.", p, n, m, expected, actual)
+				}
+			}
+		}
+	}
+	// x^(2^8) == x mod p for every irreducible p of degree 8 over GF(2). The
+	// reducible polynomials below fail the check: x⁸+1 == (x+1)⁸ has repeated
+	// factors, and x⁸+x⁷+…+1 has an irreducible factor of degree 6.
+	x := Polynomial{0x00, 0x01}
+	for _, data := range []struct {
+		poly        uint
+		irreducible bool
+	}{
+		{0x11d, true},
+		{0x11b, true},
+		{0x101, false},
+		{0x1ff, false},
+	} {
+		m := make(Polynomial, 9)
+		for i := range m {
+			m[i] = Num(data.poly >> uint(i) & 0x01)
+		}
+		p, err := f.PowModPolynomial(x, 256, m)
+		if err != nil {
+			t.Errorf("x^256 mod %v: unexpected error: %v", m, err)
+			continue
+		}
+		if isX := p.String() == x.String(); isX != data.irreducible {
+			t.Errorf("x^256 mod %v: unexpected result %v.", m, p)
+		}
+	}
+	if _, err := f.PowModPolynomial(x, 2, Polynomial{0x00}); !errors.Is(err, ErrDivideByZeroPolynomial) {
+		t.Errorf("Expected ErrDivideByZeroPolynomial, got %v.", err)
+	}
+	if _, err := f.PowModPolynomial(x, -1, Polynomial{0x01, 0x01}); err == nil {
+		t.Errorf("Expected error return value from negative exponent.")
+	}
+}
+
+func TestSubPolynomials(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	testData := []Polynomial{
+		nil,
+		{0x00},
+		{0x53},
+		{0x02, 0x01},
+		{0xff, 0x01, 0x00, 0x17, 0x02, 0x01},
+	}
+	for _, p1 := range testData {
+		for _, p2 := range testData {
+			difference := f.SubPolynomials(p1, p2)
+			if sum := f.AddPolynomials(p1, p2); difference.String() != sum.String() || len(difference) != len(sum) {
+				t.Errorf("(%v) - (%v): expected %v, actual %v.", p1, p2, sum, difference)
+			}
+			if back := f.AddPolynomials(difference, p2); f.Normalize(back).String() != f.Normalize(p1).String() {
+				t.Errorf("(%v) - (%v) + (%v): expected %v, actual %v.", p1, p2, p2, p1, back)
+			}
+		}
+	}
+}
+
+func TestAddPolynomialsInto(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	testData := []Polynomial{
+		nil,
+		{0x00},
+		{0x53},
+		{0x02, 0x01},
+		{0xff, 0x01, 0x00, 0x17, 0x02, 0x01},
+	}
+	for _, p1 := range testData {
+		for _, p2 := range testData {
+			expected := f.AddPolynomials(p1, p2)
+			// Destinations without enough capacity, and with enough capacity but
+			// the wrong length.
+			for _, dst := range []Polynomial{nil, make(Polynomial, 1), make(Polynomial, 3, 10)} {
+				if actual := f.AddPolynomialsInto(dst, p1, p2); len(actual) != len(expected) || actual.String() != expected.String() {
+					t.Errorf("AddPolynomialsInto(%#v, %v, %v): expected %#v, actual %#v.", dst, p1, p2, expected, actual)
+				}
+			}
+			// Destinations aliasing an input.
+			a1, a2 := p1.Clone(), p2.Clone()
+			if actual := f.AddPolynomialsInto(a1, a1, p2); len(actual) != len(expected) || actual.String() != expected.String() {
+				t.Errorf("AddPolynomialsInto(p1, %v, %v): expected %#v, actual %#v.", p1, p2, expected, actual)
+			}
+			if actual := f.AddPolynomialsInto(a2, p1, a2); len(actual) != len(expected) || actual.String() != expected.String() {
+				t.Errorf("AddPolynomialsInto(p2, %v, %v): expected %#v, actual %#v.", p1, p2, expected, actual)
+			}
+		}
+	}
+	// A large enough destination is reused rather than reallocated.
+	dst := make(Polynomial, 0, 8)
+	if sum := f.AddPolynomialsInto(dst, testData[3], testData[4]); &sum[0] != &dst[:1][0] {
+		t.Errorf("AddPolynomialsInto: expected the destination to be reused.")
+	}
+	// p+p is zero, also in place.
+	p := testData[4].Clone()
+	if sum := f.AddPolynomialsInto(p, p, p); !f.IsIdenticalZero(sum) {
+		t.Errorf("AddPolynomialsInto(p, p, p): expected zero, actual %v.", sum)
+	}
+}
+
+func BenchmarkAddPolynomials(b *testing.B) {
+	f, _ := NewField(0x11d, 0x02)
+	p1, p2 := benchmarkPolynomial(), benchmarkPolynomial()[:100]
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		f.AddPolynomials(p1, p2)
+	}
+}
+
+func BenchmarkAddPolynomialsInto(b *testing.B) {
+	f, _ := NewField(0x11d, 0x02)
+	p1, p2 := benchmarkPolynomial(), benchmarkPolynomial()[:100]
+	dst := make(Polynomial, len(p1))
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		dst = f.AddPolynomialsInto(dst, p1, p2)
+	}
+}
+
+func TestNormalizedArithmetic(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	testData := []Polynomial{
+		{0x00},
+		{0x00, 0x00, 0x00},
+		{0x53},
+		{0x02, 0x01},
+		{0xff, 0x01, 0x00, 0x17, 0x02, 0x01},
+		{0xff, 0x01, 0x00, 0x00},
+	}
+	for _, p := range testData {
+		if sum := f.AddPolynomialsNorm(p, p); len(sum) != 1 || sum[0] != f.Zero() {
+			t.Errorf("(%v) + (%v): expected canonical zero, actual %#v.", p, p, sum)
+		}
+		for _, zero := range []Polynomial{{0x00}, {0x00, 0x00}} {
+			if product := f.MultiplyPolynomialsNorm(p, zero); len(product) != 1 || product[0] != f.Zero() {
+				t.Errorf("(%v) × (%#v): expected canonical zero, actual %#v.", p, zero, product)
+			}
+		}
+		for _, q := range testData {
+			sum := f.AddPolynomialsNorm(p, q)
+			if expected := f.Normalize(f.AddPolynomials(p, q)); len(sum) != len(expected) || sum.String() != expected.String() {
+				t.Errorf("(%v) + (%v): expected %#v, actual %#v.", p, q, expected, sum)
+			}
+			product := f.MultiplyPolynomialsNorm(p, q)
+			if expected := f.Normalize(f.MultiplyPolynomials(p, q)); len(product) != len(expected) || product.String() != expected.String() {
+				t.Errorf("(%v) × (%v): expected %#v, actual %#v.", p, q, expected, product)
+			}
+		}
+	}
+}
+
+func TestMultiplyEmptyPolynomials(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	testData := []struct {
+		p    Polynomial
+		zero bool
+	}{
+		{nil, true},
+		{Polynomial{}, true},
+		{Polynomial{0x00}, true},
+		{Polynomial{0x53}, false},
+	}
+	for _, data1 := range testData {
+		for _, data2 := range testData {
+			product := f.MultiplyPolynomials(data1.p, data2.p)
+			if len(product) == 0 {
+				t.Errorf("(%#v) × (%#v): expected at least one coefficient, actual %#v.", data1.p, data2.p, product)
+			}
+			if data1.zero || data2.zero {
+				if !f.IsIdenticalZero(product) {
+					t.Errorf("(%#v) × (%#v): expected zero, actual %v.", data1.p, data2.p, product)
+				}
+			} else if expected := f.Mul(0x53, 0x53); len(product) != 1 || product[0] != expected {
+				t.Errorf("(%#v) × (%#v): expected %v, actual %v.", data1.p, data2.p, expected, product)
+			}
+			if normalized := f.MultiplyPolynomialsNorm(data1.p, data2.p); len(normalized) != 1 {
+				t.Errorf("(%#v) × (%#v): expected one coefficient, actual %#v.", data1.p, data2.p, normalized)
+			}
+		}
+	}
+}
+
+func TestScalePolynomial(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	p := Polynomial{0xff, 0x01, 0x00, 0x17, 0x02, 0x01}
+	if scaled := f.ScalePolynomial(f.Zero(), p); !f.IsIdenticalZero(scaled) || len(scaled) != 1 {
+		t.Errorf("0 × %v: expected zero polynomial, actual %v.", p, scaled)
+	}
+	if scaled := f.ScalePolynomial(f.One(), p); scaled.String() != p.String() {
+		t.Errorf("1 × %v: expected %v, actual %v.", p, p, scaled)
+	}
+	c := Num(0x53)
+	scaled := f.ScalePolynomial(c, p)
+	expected := f.MultiplyPolynomials(Polynomial{c}, p)
+	if scaled.String() != expected.String() {
+		t.Errorf("%v × %v: expected %v, actual %v.", c, p, expected, scaled)
+	}
+	for _, x := range []Num{0x00, 0x01, 0x02, 0x8e, 0xff} {
+		if y, z := f.EvaluatePolynomial(scaled, x), f.Mul(c, f.EvaluatePolynomial(p, x)); y != z {
+			t.Errorf("(%v × %v)(%v): expected %v, actual %v.", c, p, x, z, y)
+		}
+	}
+	// Scaling must not modify its argument.
+	if p[0] != 0xff {
+		t.Errorf("ScalePolynomial modified its argument: %v.", p)
+	}
+}
+
+func TestMakeMonic(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	testData := []Polynomial{
+		{0x01},
+		{0x53},
+		{0x02, 0x01},
+		{0xff, 0x01, 0x00, 0x17, 0x02, 0x8e},
+		{0x03, 0x00, 0xca, 0x00, 0x00},
+	}
+	for _, p := range testData {
+		monic, err := f.MakeMonic(p)
+		if err != nil {
+			t.Errorf("MakeMonic(%v): unexpected error: %v", p, err)
+			continue
+		}
+		if d := f.Degree(p); len(monic) != d+1 || monic[d] != f.One() {
+			t.Errorf("MakeMonic(%v): %v does not have leading coefficient one.", p, monic)
+			continue
+		}
+		inv, _ := f.Inv(p[f.Degree(p)])
+		if expected := f.ScalePolynomial(inv, p); expected.String() != monic.String() {
+			t.Errorf("MakeMonic(%v): expected %v, actual %v.", p, expected, monic)
+		}
+	}
+	for _, p := range []Polynomial{nil, {}, {0x00}, {0x00, 0x00}} {
+		if _, err := f.MakeMonic(p); !errors.Is(err, ErrZeroPolynomial) {
+			t.Errorf("MakeMonic(%v): expected ErrZeroPolynomial, got %v.", p, err)
+		}
+	}
+}
+
+func TestShiftPolynomial(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	testData := []Polynomial{
+		{0x00},
+		{0x53},
+		{0x02, 0x01},
+		{0xff, 0x01, 0x00, 0x17, 0x02, 0x01},
+	}
+	for _, p := range testData {
+		monomial := Polynomial{f.One()}
+		for k := 0; k <= 5; k++ {
+			expected := f.MultiplyPolynomials(p, monomial)
+			actual := f.ShiftPolynomial(p, k)
+			if expected.String() != actual.String() {
+				t.Errorf("(%v) × x^%d: expected %v, actual %v.", p, k, expected, actual)
+			}
+			// Shifting back recovers the original polynomial.
+			if back := f.ShiftPolynomial(actual, -k); back.String() != p.String() {
+				t.Errorf("(%v) × x^%d / x^%d: expected %v, actual %v.", p, k, k, p, back)
+			}
+			monomial = f.ShiftPolynomial(monomial, 1)
+		}
+	}
+	p := Polynomial{0xff, 0x01, 0x00, 0x17}
+	if shifted := f.ShiftPolynomial(p, -2); shifted.String() != (Polynomial{0x00, 0x17}).String() {
+		t.Errorf("(%v) / x^2: expected %v, actual %v.", p, Polynomial{0x00, 0x17}, shifted)
+	}
+	if shifted := f.ShiftPolynomial(p, -4); !f.IsIdenticalZero(shifted) || len(shifted) != 1 {
+		t.Errorf("(%v) / x^4: expected zero polynomial, actual %v.", p, shifted)
+	}
+}
+
+func TestDerivative(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	testData := []struct {
+		p                  Polynomial
+		expectedDerivative string
+	}{
+		{Polynomial{}, "0"},
+		{Polynomial{0x17}, "0"},
+		{Polynomial{0x17, 0x02}, "10"},
+		{Polynomial{0x17, 0x02, 0x03}, "10"},
+		{Polynomial{0x17, 0x02, 0x03, 0x04}, "100 x^2 + 10"},
+		{Polynomial{0x00, 0x00, 0x01, 0x00, 0x01}, "0"},
+	}
+	for _, data := range testData {
+		if d := f.Derivative(data.p); d.String() != data.expectedDerivative {
+			t.Errorf("Derivative(%v): expected %v, got %v.", data.p, data.expectedDerivative, d)
+		}
+	}
+	// The derivative of a square is zero in characteristic two.
+	p := Polynomial{0xff, 0x01, 0x00, 0x17, 0x02, 0x01}
+	if d := f.Derivative(f.MultiplyPolynomials(p, p)); !f.IsIdenticalZero(d) {
+		t.Errorf("Derivative of (%v)²: expected 0, got %v.", p, d)
+	}
+}
+
+func TestEvaluateAll(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	xs := make([]Num, 256)
+	for i := range xs {
+		xs[i] = Num(i)
+	}
+	testData := []Polynomial{
+		nil,
+		{},
+		{0x00},
+		{0x53},
+		{0x02, 0x01},
+		{0xff, 0x01, 0x00, 0x17, 0x02, 0x01},
+		{0x8e, 0x00, 0xca, 0x00, 0x00},
+	}
+	for _, p := range testData {
+		values := f.EvaluateAll(p, xs)
+		if len(values) != len(xs) {
+			t.Errorf("EvaluateAll(%v): expected %d values, actual %d.", p, len(xs), len(values))
+			continue
+		}
+		for i, x := range xs {
+			if expected := f.EvaluatePolynomial(p, x); expected != values[i] {
+				t.Errorf("(%v)(%v): expected %v, actual %v.", p, x, expected, values[i])
+			}
+		}
+	}
+	if values := f.EvaluateAll(Polynomial{0x01}, nil); len(values) != 0 {
+		t.Errorf("EvaluateAll with no points: expected no values, actual %v.", values)
+	}
+}
+
+func TestRoots(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	// Roots should agree with exhaustive evaluation.
+	testData := []Polynomial{
+		Polynomial{0x01},
+		Polynomial{0x00, 0x01},
+		Polynomial{0x02, 0x01, 0x01},
+		Polynomial{0xff, 0x01, 0x00, 0x17, 0x02, 0x01},
+		f.MultiplyPolynomials(Polynomial{0x07, 0x01}, Polynomial{0x00, 0x01}),
+	}
+	for _, p := range testData {
+		var expected []Num
+		for i := uint(0); i < 256; i++ {
+			if f.EvaluatePolynomial(p, Num(i)) == f.Zero() {
+				expected = append(expected, Num(i))
+			}
+		}
+		if actual := f.Roots(p); fmt.Sprint(actual) != fmt.Sprint(expected) {
+			t.Errorf("Roots(%v): expected %v, got %v.", p, expected, actual)
+		}
+	}
+	// A non-zero constant and an irreducible quadratic have no roots.
+	if roots := f.Roots(Polynomial{0x17}); len(roots) != 0 {
+		t.Errorf("Roots(10111): expected none, got %v.", roots)
+	}
+	if roots := f.Roots(Polynomial{0x20, 0x01, 0x01}); len(roots) != 0 {
+		t.Errorf("Roots(x^2 + x + 100000): expected none, got %v.", roots)
+	}
+	// Every element is a root of x^256 - x.
+	all := make(Polynomial, 257)
+	all[1], all[256] = f.One(), f.One()
+	roots := f.Roots(all)
+	if len(roots) != 256 {
+		t.Errorf("Roots(x^256 - x): expected 256 roots, got %d.", len(roots))
+	}
+	for i, r := range roots {
+		if r != Num(i) {
+			t.Errorf("Roots(x^256 - x): expected %v at position %d, got %v.", Num(i), i, r)
+		}
+	}
+	// Repeated roots are reported once.
+	p := Polynomial{0x05, 0x01}
+	p = f.MultiplyPolynomials(p, f.MultiplyPolynomials(p, p))
+	if roots := f.Roots(p); fmt.Sprint(roots) != "[101]" {
+		t.Errorf("Roots(%v): expected [101], got %v.", p, roots)
+	}
+}
+
+func TestCountRoots(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	random := rand.New(rand.NewSource(1))
+	testData := []Polynomial{
+		nil,
+		{0x00},
+		{0x01},
+		{0x53},
+		{0x00, 0x01},
+		{0x20, 0x01, 0x01},             // Irreducible.
+		{0x00, 0x00, 0x01},             // Repeated root.
+		{0x01, 0x00, 0x00, 0x01},       // Three cube roots of one.
+		{0x01, 0x01, 0x00, 0x00, 0x01}, // x⁴+x+1.
+	}
+	// x^256 - x splits completely.
+	all := make(Polynomial, 257)
+	all[1], all[256] = 0x01, 0x01
+	testData = append(testData, all)
+	for i := 0; i < 20; i++ {
+		p := make(Polynomial, 1+random.Intn(30))
+		for j := range p {
+			p[j] = Num(random.Intn(256))
+		}
+		testData = append(testData, p)
+	}
+	for _, p := range testData {
+		if expected, actual := len(f.Roots(p)), f.CountRoots(p); expected != actual {
+			t.Errorf("CountRoots(%v): expected %d, got %d.", p, expected, actual)
+		}
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	testData := []Polynomial{
+		Polynomial{0x17},
+		Polynomial{0x00},
+		Polynomial{0x17, 0x01, 0x02},
+		Polynomial{0xff, 0x01, 0x00, 0x17, 0x02, 0x01},
+	}
+	for _, p := range testData {
+		xs := make([]Num, len(p))
+		ys := make([]Num, len(p))
+		for i := range xs {
+			xs[i] = f.Exp(3 * i)
+			ys[i] = f.EvaluatePolynomial(p, xs[i])
+		}
+		actual, err := f.Interpolate(xs, ys)
+		if err != nil {
+			t.Errorf("Interpolating %v: got error %v", p, err)
+		}
+		if actual.String() != p.String() {
+			t.Errorf("Interpolating %v: got %v.", p, actual)
+		}
+	}
+	if _, err := f.Interpolate([]Num{0x01, 0x02}, []Num{0x01}); err == nil {
+		t.Errorf("Expected error for mismatched lengths.")
+	}
+	if _, err := f.Interpolate([]Num{0x01, 0x02, 0x01}, []Num{0x01, 0x02, 0x03}); err == nil {
+		t.Errorf("Expected error for duplicate x-values.")
+	}
+}
+
+func TestParsePolynomial(t *testing.T) {
+	// These are the string representations used in the examples.
+	testData := []string{
+		"0",
+		"1",
+		"x",
+		"x^2 + 1",
+		"10 x^2 + x + 10111",
+		"10001110",
+		"x + 10011001",
+		"10111 x + 11111101",
+		"x^3 + 10 x^2 + 10110 x + 10",
+		"10001110 x^2 + 1000111 x + 11001100",
+		"x^5 + 10 x^4 + 10111 x^3 + x + 11111111",
+		"x^5 + 10 x^4 + 10111 x^3 + x^2 + x + 11111110",
+		"x^7 + 10 x^6 + 10110 x^5 + 10 x^4 + 10110 x^3 + 11111111 x^2 + x + 11111111",
+	}
+	for _, s := range testData {
+		p, err := ParsePolynomial(s)
+		if err != nil {
+			t.Errorf("ParsePolynomial(%q): got error %v", s, err)
+			continue
+		}
+		if p.String() != s {
+			t.Errorf("ParsePolynomial(%q): got %v.", s, p)
+		}
+	}
+	p, err := ParsePolynomial("10 x^4")
+	if err != nil || len(p) != 5 || p[4] != 0x02 {
+		t.Errorf("ParsePolynomial(\"10 x^4\"): got %v, error %v.", p, err)
+	}
+	for _, s := range []string{"", "x^", "x^-1", "y", "2 x", "10 y^2", "x + x", "x +1", "1 1 x", "x^2 + ", "100000000", "x^65537", "x^4000000000"} {
+		if p, err := ParsePolynomial(s); err == nil {
+			t.Errorf("ParsePolynomial(%q): expected error, got %v.", s, p)
+		}
+	}
+}
+
+func TestReduce(t *testing.T) {
+	for _, f := range []*Field{NewQRField(), NewAESField()} {
+		// x^k reduces to the k-th power of x, which is Num(0x02).
+		for k := 0; k < 600; k++ {
+			monomial := make(Polynomial, k+1)
+			monomial[k] = f.One()
+			if expected, actual := f.Pow(0x02, k), f.Reduce(monomial); expected != actual {
+				t.Errorf("%v: x^%d: expected %v, actual %v.", f.Polynomial(), k, expected, actual)
+			}
+		}
+		// The defining polynomial itself reduces to zero.
+		p := make(Polynomial, 9)
+		for i := range p {
+			p[i] = Num(uint(f.Polynomial())>>uint(i)) & 0x01
+		}
+		if r := f.Reduce(p); r != f.Zero() {
+			t.Errorf("%v: expected the defining polynomial to reduce to zero, got %v.", f.Polynomial(), r)
+		}
+		// Polynomials of degree below 8 are field elements already.
+		for i := 0; i < 256; i++ {
+			q := make(Polynomial, 8)
+			for j := range q {
+				q[j] = Num(i>>uint(j)) & 0x01
+			}
+			if r := f.Reduce(q); r != Num(i) {
+				t.Errorf("%v: Reduce(%v): expected %v, actual %v.", f.Polynomial(), q, Num(i), r)
+			}
+		}
+		if r := f.Reduce(nil); r != f.Zero() {
+			t.Errorf("%v: Reduce(nil): expected 0, actual %v.", f.Polynomial(), r)
+		}
+	}
+	f := NewQRField()
+	if r := f.Reduce(Polynomial{0, 0, 0, 0, 0, 0, 0, 0, 1}); r != 0x1d {
+		t.Errorf("x⁸: expected 11101, actual %v.", r)
+	}
+}
+
+func TestPolynomialFromRoots(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	testData := [][]Num{
+		nil,
+		{0x00},
+		{0x53},
+		{0x01, 0x02, 0x03},
+		{0xff, 0x00, 0x1d, 0x80, 0xca},
+	}
+	for _, roots := range testData {
+		p := f.PolynomialFromRoots(roots)
+		if f.Degree(p) != len(roots) || p[len(p)-1] != f.One() {
+			t.Errorf("PolynomialFromRoots(%v): expected monic of degree %d, actual %v.", roots, len(roots), p)
+		}
+		expected := append([]Num{}, roots...)
+		sort.Slice(expected, func(i, j int) bool { return expected[i] < expected[j] })
+		if actual := f.Roots(p); fmt.Sprint(actual) != fmt.Sprint(expected) {
+			t.Errorf("Roots(PolynomialFromRoots(%v)): expected %v, actual %v.", roots, expected, actual)
+		}
+	}
+	// A repeated root gives a repeated factor: (x+3)²(x+5) == (x²+5)(x+5).
+	p := f.PolynomialFromRoots([]Num{0x03, 0x05, 0x03})
+	if expected := f.MultiplyPolynomials(Polynomial{0x05, 0x00, 0x01}, Polynomial{0x05, 0x01}); p.String() != expected.String() {
+		t.Errorf("PolynomialFromRoots([3 5 3]): expected %v, actual %v.", expected, p)
+	}
+	factors, _ := f.SquareFreeFactorization(p)
+	single, double := Polynomial{0x05, 0x01}, Polynomial{0x03, 0x01}
+	if len(factors) != 2 || factors[0].String() != single.String() || factors[1].String() != double.String() {
+		t.Errorf("SquareFreeFactorization(%v): expected [x+5, x+3], actual %v.", p, factors)
+	}
+}
+
+func TestMinimalPolynomial(t *testing.T) {
+	for _, parameters := range []struct {
+		polynomial Irreducible
+		generator  Num
+	}{
+		{0x11d, 0x02},
+		{0x11b, 0x03},
+	} {
+		f, err := NewField(parameters.polynomial, parameters.generator)
+		if err != nil {
+			t.Errorf("Could not create GF[2⁸]: %v.", err)
+			continue
+		}
+		for i := uint(0); i < 256; i++ {
+			x := Num(i)
+			p := f.MinimalPolynomial(x)
+			if f.Degree(p) != len(f.Conjugates(x)) {
+				t.Errorf("MinimalPolynomial(%v): degree of %v is not %d.", x, p, len(f.Conjugates(x)))
+			}
+			bits := uint(0)
+			for j, c := range p {
+				if c != f.Zero() && c != f.One() {
+					t.Errorf("MinimalPolynomial(%v): coefficient %v not in GF(2).", x, c)
+				}
+				bits = bits | uint(c)<<uint(j)
+			}
+			if y := f.EvaluatePolynomial(p, x); y != f.Zero() {
+				t.Errorf("MinimalPolynomial(%v): evaluates to %v at %v.", x, y, x)
+			}
+			// The element x is a root of the irreducible polynomial
+			// defining the field, which is therefore its minimal
+			// polynomial; for 0x11d, x is also the generator.
+			if x == 0x02 && Irreducible(bits) != f.Polynomial() {
+				t.Errorf("MinimalPolynomial(%v): expected %v, got %v.", x, f.Polynomial(), Irreducible(bits))
+			}
+		}
+	}
 }
@@ -0,0 +1,317 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gf256
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUncorrectable is returned when a received word has too many errors
+// to be corrected.
+var ErrUncorrectable = errors.New("gf256: too many errors to correct")
+
+// RSDecoder corrects errors in Reed-Solomon codewords produced by RSEncode.
+type RSDecoder struct {
+	f         *Field
+	numParity int
+}
+
+// NewRSDecoder returns a decoder for codewords with numParity parity
+// symbols, which can correct up to numParity/2 errors. NewRSDecoder panics
+// if numParity is negative.
+func (f *Field) NewRSDecoder(numParity int) *RSDecoder {
+	if numParity < 0 {
+		panic(fmt.Sprintf("NewRSDecoder: negative number of parity symbols %d.", numParity))
+	}
+	return &RSDecoder{f: f, numParity: numParity}
+}
+
+// RSGeneratorPoly returns the monic Reed-Solomon generator polynomial
+// (x-α^0)(x-α^1)…(x-α^(numSymbols-1)) of degree numSymbols, where α is the
+// generator of the field f. This is the generator polynomial used by QR
+// codes when f is NewField(0x11d, 0x2).
+func (f *Field) RSGeneratorPoly(numSymbols int) Polynomial {
+	var roots []Num
+	for i := 0; i < numSymbols; i++ {
+		roots = append(roots, f.Exp(i))
+	}
+	return f.PolynomialFromRoots(roots)
+}
+
+// RSEncode returns the systematic Reed-Solomon codeword for message with
+// numParity parity symbols: message×x^numParity plus the remainder of its
+// division by RSGeneratorPoly(numParity). The parity symbols occupy
+// positions 0 to numParity-1 of the codeword and the message follows.
+func (f *Field) RSEncode(message Polynomial, numParity int) Polynomial {
+	shifted := f.ShiftPolynomial(message, numParity)
+	rem, _ := f.ModPolynomial(shifted, f.RSGeneratorPoly(numParity))
+	// Subtracting the remainder makes the codeword divisible by the
+	// generator polynomial; subtraction is addition in GF[2⁸].
+	return f.AddPolynomials(shifted, rem)
+}
+
+// RSVerify returns true if codeword is a valid Reed-Solomon codeword with
+// numParity parity symbols, i.e. if all its syndromes are zero. This is
+// cheaper than decoding when only a yes or no answer is needed.
+func (f *Field) RSVerify(codeword Polynomial, numParity int) bool {
+	for _, s := range f.rsSyndromes(codeword, numParity) {
+		if s != f.Zero() {
+			return false
+		}
+	}
+	return true
+}
+
+// Interleave returns the symbols of blocks in round-robin order: the first
+// symbol of every block, then the second symbol of every block, and so on,
+// skipping blocks that have run out of symbols. This is the interleaving
+// used by QR and Data Matrix codes for data split into several RS blocks.
+func Interleave(blocks [][]Num) []Num {
+	var data []Num
+	for i := 0; ; i++ {
+		n := len(data)
+		for _, block := range blocks {
+			if i < len(block) {
+				data = append(data, block[i])
+			}
+		}
+		if len(data) == n {
+			return data
+		}
+	}
+}
+
+// Deinterleave reverses Interleave, splitting data into blocks of the given
+// sizes. It returns an error if a size is negative or if the sizes do not
+// add up to the length of data.
+func Deinterleave(data []Num, blockSizes []int) ([][]Num, error) {
+	total := 0
+	for _, size := range blockSizes {
+		if size < 0 {
+			return nil, fmt.Errorf("Deinterleave: negative block size %d.", size)
+		}
+		total += size
+	}
+	if total != len(data) {
+		return nil, fmt.Errorf("Deinterleave: length mismatch: %d != %d.", len(data), total)
+	}
+	blocks := make([][]Num, len(blockSizes))
+	for j, size := range blockSizes {
+		blocks[j] = make([]Num, 0, size)
+	}
+	for i := 0; len(data) > 0; i++ {
+		for j, size := range blockSizes {
+			if i < size {
+				blocks[j] = append(blocks[j], data[0])
+				data = data[1:]
+			}
+		}
+	}
+	return blocks, nil
+}
+
+// BerlekampMassey returns the minimal-degree error-locator polynomial Λ
+// for the given syndromes, where syndromes[j] is the received word
+// evaluated at α^j. Λ has constant term one and its roots are the inverses
+// α^(-i) of α^i for every error position i.
+func (f *Field) BerlekampMassey(syndromes []Num) Polynomial {
+	locator := Polynomial{f.One()}  // Current locator, C in the literature.
+	previous := Polynomial{f.One()} // Locator before the last length change, B.
+	length := 0                     // Number of errors assumed so far, L.
+	shift := 1                      // Steps since the last length change, m.
+	lastDiscrepancy := f.One()      // Discrepancy at the last length change, b.
+	for n, syndrome := range syndromes {
+		discrepancy := syndrome
+		for i := 1; i <= length && i < len(locator); i++ {
+			discrepancy = f.Add(discrepancy, f.Mul(locator[i], syndromes[n-i]))
+		}
+		if discrepancy == f.Zero() {
+			shift++
+			continue
+		}
+		// Compute locator - discrepancy/lastDiscrepancy × x^shift × previous.
+		scale, _ := f.Div(discrepancy, lastDiscrepancy)
+		correction := make(Polynomial, shift+len(previous))
+		for i, c := range previous {
+			correction[i+shift] = f.Mul(scale, c)
+		}
+		updated := f.AddPolynomials(locator, correction)
+		if 2*length <= n {
+			length = n + 1 - length
+			previous = locator
+			lastDiscrepancy = discrepancy
+			shift = 1
+		} else {
+			shift++
+		}
+		locator = updated
+	}
+	return f.Normalize(locator)
+}
+
+// Forney returns the error magnitudes for the given syndromes and error
+// locator, one for each entry of positions. Each position is given as the
+// error locator value α^i for an error at coefficient i of the received
+// word, i.e. the inverse of a root of locator. Forney returns an error if a
+// magnitude cannot be computed, which indicates an uncorrectable word.
+func (f *Field) Forney(syndromes []Num, locator Polynomial, positions []Num) ([]Num, error) {
+	// The error evaluator is Ω(x) = S(x)×Λ(x) mod x^len(syndromes).
+	evaluator := f.MultiplyPolynomials(Polynomial(syndromes), locator)
+	if len(evaluator) > len(syndromes) {
+		evaluator = evaluator[:len(syndromes)]
+	}
+	derivative := f.Derivative(locator)
+	magnitudes := make([]Num, len(positions))
+	for k, x := range positions {
+		xInv, err := f.Inv(x)
+		if err != nil {
+			return nil, fmt.Errorf("Forney: invalid error position %v.", x)
+		}
+		// Since the syndromes start at α^0, the magnitude is
+		// X×Ω(X⁻¹)/Λ'(X⁻¹) for error locator X.
+		d := f.EvaluatePolynomial(derivative, xInv)
+		q, err := f.Div(f.EvaluatePolynomial(evaluator, xInv), d)
+		if err != nil {
+			return nil, fmt.Errorf("Forney: locator derivative is zero at %v.", xInv)
+		}
+		magnitudes[k] = f.Mul(x, q)
+	}
+	return magnitudes, nil
+}
+
+// Decode corrects errors in the received codeword, where received[i] is the
+// coefficient of x^i as in the Polynomial returned by RSEncode. It returns
+// the corrected message, which is received without its leading numParity
+// parity symbols, and the number of corrected errors. Decode returns
+// ErrUncorrectable if received has more errors than can be corrected.
+func (d *RSDecoder) Decode(received []byte) ([]byte, int, error) {
+	r, err := d.symbols(received)
+	if err != nil {
+		return nil, 0, err
+	}
+	numErrors, err := d.f.rsCorrect(r, d.numParity, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	return d.message(r), numErrors, nil
+}
+
+// DecodeWithErasures is like Decode but also takes the positions of known
+// erased symbols in received. It can correct e errors and ρ erasures as
+// long as 2e+ρ ≤ numParity, and returns ErrUncorrectable otherwise.
+func (d *RSDecoder) DecodeWithErasures(received []byte, erasures []int) ([]byte, error) {
+	r, err := d.symbols(received)
+	if err != nil {
+		return nil, err
+	}
+	erased := make(map[int]bool)
+	for _, i := range erasures {
+		if i < 0 || i >= len(r) {
+			return nil, fmt.Errorf("DecodeWithErasures: invalid erasure position %d.", i)
+		}
+		if erased[i] {
+			return nil, fmt.Errorf("DecodeWithErasures: duplicate erasure position %d.", i)
+		}
+		erased[i] = true
+	}
+	if _, err := d.f.rsCorrect(r, d.numParity, erasures); err != nil {
+		return nil, err
+	}
+	return d.message(r), nil
+}
+
+// symbols converts the received bytes to a Polynomial, or returns an error
+// if received has invalid length or contains bytes outside the field.
+func (d *RSDecoder) symbols(received []byte) (Polynomial, error) {
+	if len(received) < d.numParity || len(received) > len(d.f.expTable) {
+		return nil, fmt.Errorf("Invalid codeword length %d.", len(received))
+	}
+	r := make(Polynomial, len(received))
+	for i, b := range received {
+		if int(b) >= len(d.f.logTable) {
+			return nil, fmt.Errorf("Invalid symbol %v at position %d.", Num(b), i)
+		}
+		r[i] = Num(b)
+	}
+	return r, nil
+}
+
+// message returns the message part of the codeword r as bytes.
+func (d *RSDecoder) message(r Polynomial) []byte {
+	message := make([]byte, len(r)-d.numParity)
+	for i, n := range r[d.numParity:] {
+		message[i] = byte(n)
+	}
+	return message
+}
+
+// rsSyndromes returns the received word evaluated at α^0…α^(numParity-1).
+func (f *Field) rsSyndromes(received Polynomial, numParity int) []Num {
+	points := make([]Num, numParity)
+	for j := range points {
+		points[j] = f.Exp(j)
+	}
+	return f.EvaluateAll(received, points)
+}
+
+// rsCorrect corrects the errors and erasures in r in place and returns the
+// number of corrected symbols. The erasures are known error positions.
+func (f *Field) rsCorrect(r Polynomial, numParity int, erasures []int) (int, error) {
+	if len(erasures) > numParity {
+		return 0, ErrUncorrectable
+	}
+	syndromes := f.rsSyndromes(r, numParity)
+	if f.IsIdenticalZero(syndromes) {
+		return 0, nil
+	}
+	// The erasure locator is Γ(x) = ∏(1 - α^i x) for erasure positions i.
+	erasureLocator := Polynomial{f.One()}
+	for _, i := range erasures {
+		erasureLocator = f.MultiplyPolynomials(erasureLocator, Polynomial{f.One(), f.Exp(i)})
+	}
+	// Multiplying the syndromes by Γ(x) cancels the contribution of the
+	// erasures from all but the first len(erasures) terms, leaving the
+	// Forney syndromes of the unknown errors.
+	forneySyndromes := f.MultiplyPolynomials(Polynomial(syndromes), erasureLocator)
+	errorLocator := f.BerlekampMassey(forneySyndromes[len(erasures):numParity])
+	if 2*f.Degree(errorLocator)+len(erasures) > numParity {
+		return 0, ErrUncorrectable
+	}
+	locator := f.MultiplyPolynomials(errorLocator, erasureLocator)
+	var positions []Num
+	for _, root := range f.Roots(locator) {
+		x, _ := f.Inv(root) // The root is non-zero since locator[0] == 1.
+		if i, _ := f.Log(x); i >= len(r) {
+			return 0, ErrUncorrectable
+		}
+		positions = append(positions, x)
+	}
+	if len(positions) != f.Degree(locator) {
+		return 0, ErrUncorrectable
+	}
+	magnitudes, err := f.Forney(syndromes, locator, positions)
+	if err != nil {
+		return 0, ErrUncorrectable
+	}
+	for k, x := range positions {
+		i, _ := f.Log(x)
+		r[i] = f.Add(r[i], magnitudes[k])
+	}
+	if !f.IsIdenticalZero(f.rsSyndromes(r, numParity)) {
+		return 0, ErrUncorrectable
+	}
+	return len(positions), nil
+}
@@ -0,0 +1,451 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gf256
+
+import "bytes"
+import "errors"
+import "fmt"
+import "math/rand"
+import "sort"
+import "testing"
+
+func ExampleField_RSGeneratorPoly() {
+	f, _ := NewField(0x11d, 0x2)
+	fmt.Println(f.ToString(f.RSGeneratorPoly(7)))
+	// Output:
+	// x^7 + α^87 x^6 + α^229 x^5 + α^146 x^4 + α^149 x^3 + α^238 x^2 + α^102 x + α^21
+}
+
+func ExampleField_RSEncode() {
+	f, _ := NewField(0x11d, 0x2)
+	message := Polynomial{0x01, 0x02, 0x03}
+	fmt.Println(f.RSEncode(message, 4))
+	// Output:
+	// 11 x^6 + 10 x^5 + x^4 + 101101 x^3 + 10010001 x^2 + 11101 x + 10100001
+}
+
+func TestRSGeneratorPoly(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	// Generator polynomials from the QR code specification, ISO/IEC 18004
+	// Annex A, with coefficients as exponents of α in descending order.
+	testData := []struct {
+		numSymbols int
+		exponents  []int
+	}{
+		{0, []int{0}},
+		{1, []int{0, 0}},
+		{2, []int{0, 25, 1}},
+		{7, []int{0, 87, 229, 146, 149, 238, 102, 21}},
+		{10, []int{0, 251, 67, 46, 61, 118, 70, 64, 94, 32, 45}},
+		{13, []int{0, 74, 152, 176, 100, 86, 100, 106, 104, 130, 218, 206, 140, 78}},
+		{15, []int{0, 8, 183, 61, 91, 202, 37, 51, 58, 58, 237, 140, 124, 5, 99, 105}},
+	}
+	for _, data := range testData {
+		expected := make(Polynomial, len(data.exponents))
+		for i, e := range data.exponents {
+			expected[len(expected)-1-i] = f.Exp(e)
+		}
+		actual := f.RSGeneratorPoly(data.numSymbols)
+		if actual.String() != expected.String() {
+			t.Errorf("RSGeneratorPoly(%d): expected %v, got %v.",
+				data.numSymbols, f.ToString(expected), f.ToString(actual))
+		}
+	}
+}
+
+// reversed returns the bytes of b in reverse order as a Polynomial, so that
+// the first byte becomes the highest-order coefficient as in QR codes.
+func reversed(b []byte) Polynomial {
+	p := make(Polynomial, len(b))
+	for i, n := range b {
+		p[len(b)-1-i] = Num(n)
+	}
+	return p
+}
+
+func TestRSEncode(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	// The “HELLO WORLD” version 1-M QR code from the well-known tutorial
+	// at thonky.com, with data and error correction codewords in order.
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	parity := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	codeword := f.RSEncode(reversed(data), len(parity))
+	expected := reversed(append(append([]byte{}, data...), parity...))
+	if codeword.String() != expected.String() {
+		t.Errorf("RSEncode: expected %v, got %v.", expected, codeword)
+	}
+	// Every codeword is divisible by the generator polynomial.
+	for numParity := 0; numParity < 20; numParity++ {
+		codeword := f.RSEncode(reversed(data), numParity)
+		if len(codeword) != len(data)+numParity {
+			t.Errorf("RSEncode with %d parity symbols: unexpected length %d.", numParity, len(codeword))
+		}
+		_, rem, err := f.DividePolynomials(codeword, f.RSGeneratorPoly(numParity))
+		if err != nil {
+			t.Errorf("Dividing codeword by generator: got error %v", err)
+		}
+		if !f.IsIdenticalZero(rem) {
+			t.Errorf("RSEncode with %d parity symbols: non-zero remainder %v.", numParity, rem)
+		}
+	}
+}
+
+func TestRSVerify(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	for _, numParity := range []int{1, 2, 10} {
+		codeword := f.RSEncode(reversed(data), numParity)
+		if !f.RSVerify(codeword, numParity) {
+			t.Errorf("RSVerify(%v, %d): expected true for encoded codeword.", codeword, numParity)
+		}
+		for i := range codeword {
+			for _, e := range []Num{0x01, 0x53, 0xff} {
+				corrupted := codeword.Clone()
+				corrupted[i] = f.Add(corrupted[i], e)
+				if f.RSVerify(corrupted, numParity) {
+					t.Errorf("RSVerify(%v, %d): expected false after changing symbol %d.", corrupted, numParity, i)
+				}
+			}
+		}
+	}
+}
+
+func ExampleInterleave() {
+	blocks := [][]Num{{1, 2, 3}, {4, 5}, {6, 7, 8, 9}}
+	fmt.Println(Interleave(blocks))
+	// Output: [1 100 110 10 101 111 11 1000 1001]
+}
+
+func TestInterleave(t *testing.T) {
+	testData := [][][]Num{
+		{},
+		{{}},
+		{{0x01, 0x02, 0x03}},
+		{{0x01, 0x02, 0x03}, {0x04, 0x05, 0x06}},
+		{{0x01, 0x02}, {0x03, 0x04, 0x05}, {}, {0x06, 0x07, 0x08, 0x09}},
+		// The “HELLO WORLD” 5-Q QR code splits its data into two blocks
+		// of 15 codewords followed by two blocks of 16 codewords.
+		{make([]Num, 15), make([]Num, 15), make([]Num, 16), make([]Num, 16)},
+	}
+	for _, blocks := range testData {
+		sizes := make([]int, len(blocks))
+		total := 0
+		for j, block := range blocks {
+			for i := range block {
+				block[i] = Num(total + i)
+			}
+			sizes[j] = len(block)
+			total += len(block)
+		}
+		data := Interleave(blocks)
+		if len(data) != total {
+			t.Errorf("Interleave(%v): expected %d symbols, got %d.", blocks, total, len(data))
+		}
+		actual, err := Deinterleave(data, sizes)
+		if err != nil {
+			t.Errorf("Deinterleave(%v, %v): unexpected error %v.", data, sizes, err)
+			continue
+		}
+		if fmt.Sprint(actual) != fmt.Sprint(blocks) {
+			t.Errorf("Deinterleave(Interleave(%v)): got %v.", blocks, actual)
+		}
+	}
+	if _, err := Deinterleave([]Num{0x01, 0x02}, []int{1, 2}); err == nil {
+		t.Errorf("Deinterleave with mismatched sizes: expected error, got nil.")
+	}
+	if _, err := Deinterleave([]Num{0x01, 0x02}, []int{3, -1}); err == nil {
+		t.Errorf("Deinterleave with negative size: expected error, got nil.")
+	}
+}
+
+// errorPositions returns the sorted error positions corresponding to the
+// roots of the error-locator polynomial.
+func errorPositions(f *Field, locator Polynomial) []int {
+	var positions []int
+	for _, r := range f.Roots(locator) {
+		log, _ := f.Log(r)
+		positions = append(positions, (255-log)%255)
+	}
+	sort.Ints(positions)
+	return positions
+}
+
+func TestBerlekampMassey(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	message := reversed([]byte("Reed-Solomon over GF[2^8]"))
+	numParity := 8
+	codeword := f.RSEncode(message, numParity)
+	if s := f.rsSyndromes(codeword, numParity); f.Degree(f.BerlekampMassey(s)) != 0 {
+		t.Errorf("Expected constant locator for a valid codeword.")
+	}
+	testData := [][]int{
+		{0},
+		{5},
+		{3, 17},
+		{0, len(codeword) - 1},
+		{2, 9, 20},
+		{1, 11, 22, 30},
+	}
+	for _, positions := range testData {
+		received := append(Polynomial{}, codeword...)
+		for i, position := range positions {
+			received[position] = f.Add(received[position], Num(0x11+i))
+		}
+		locator := f.BerlekampMassey(f.rsSyndromes(received, numParity))
+		if f.Degree(locator) != len(positions) {
+			t.Errorf("Errors at %v: unexpected locator %v.", positions, locator)
+		}
+		if locator[0] != f.One() {
+			t.Errorf("Errors at %v: locator %v does not have constant term one.", positions, locator)
+		}
+		if actual := errorPositions(f, locator); fmt.Sprint(actual) != fmt.Sprint(positions) {
+			t.Errorf("Errors at %v: locator roots give positions %v.", positions, actual)
+		}
+	}
+}
+
+func TestForney(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	message := reversed([]byte("Reed-Solomon over GF[2^8]"))
+	numParity := 8
+	codeword := f.RSEncode(message, numParity)
+	testData := []struct {
+		positions  []int
+		magnitudes []Num
+	}{
+		{[]int{0}, []Num{0x01}},
+		{[]int{7}, []Num{0xff}},
+		{[]int{3, 17}, []Num{0x11, 0x80}},
+		{[]int{0, 32}, []Num{0x02, 0x03}},
+		{[]int{2, 9, 20}, []Num{0x55, 0xaa, 0x17}},
+		{[]int{1, 11, 22, 30}, []Num{0x01, 0x02, 0x04, 0x08}},
+	}
+	for _, data := range testData {
+		received := append(Polynomial{}, codeword...)
+		for i, position := range data.positions {
+			received[position] = f.Add(received[position], data.magnitudes[i])
+		}
+		s := f.rsSyndromes(received, numParity)
+		locator := f.BerlekampMassey(s)
+		var positions []Num
+		for _, r := range f.Roots(locator) {
+			x, _ := f.Inv(r)
+			positions = append(positions, x)
+		}
+		magnitudes, err := f.Forney(s, locator, positions)
+		if err != nil {
+			t.Errorf("Errors at %v: got error %v", data.positions, err)
+			continue
+		}
+		for k, x := range positions {
+			i, _ := f.Log(x)
+			received[i] = f.Add(received[i], magnitudes[k])
+		}
+		if received.String() != codeword.String() {
+			t.Errorf("Errors at %v: corrected to %v, expected %v.", data.positions, received, codeword)
+		}
+	}
+	if _, err := f.Forney([]Num{0x01, 0x02}, Polynomial{0x01, 0x01}, []Num{0x00}); err == nil {
+		t.Errorf("Expected error for zero error position.")
+	}
+}
+
+// fromBytes converts bytes to a Polynomial with b[i] as coefficient of x^i.
+func fromBytes(b []byte) Polynomial {
+	p := make(Polynomial, len(b))
+	for i, n := range b {
+		p[i] = Num(n)
+	}
+	return p
+}
+
+// toBytes converts a Polynomial with coefficients below 256 to bytes.
+func toBytes(p Polynomial) []byte {
+	b := make([]byte, len(p))
+	for i, n := range p {
+		b[i] = byte(n)
+	}
+	return b
+}
+
+func TestRSDecoder(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	message := []byte("Reed-Solomon over GF[2^8]")
+	random := rand.New(rand.NewSource(1))
+	for _, numParity := range []int{0, 1, 2, 7, 10, 16} {
+		d := f.NewRSDecoder(numParity)
+		codeword := toBytes(f.RSEncode(fromBytes(message), numParity))
+		for numErrors := 0; numErrors <= numParity/2; numErrors++ {
+			for trial := 0; trial < 20; trial++ {
+				received := append([]byte{}, codeword...)
+				for _, i := range random.Perm(len(received))[:numErrors] {
+					received[i] ^= byte(1 + random.Intn(255))
+				}
+				decoded, corrected, err := d.Decode(received)
+				if err != nil {
+					t.Errorf("%d errors with %d parity symbols: got error %v", numErrors, numParity, err)
+					continue
+				}
+				if corrected != numErrors {
+					t.Errorf("%d errors with %d parity symbols: corrected %d.", numErrors, numParity, corrected)
+				}
+				if !bytes.Equal(decoded, message) {
+					t.Errorf("%d errors with %d parity symbols: decoded %q.", numErrors, numParity, decoded)
+				}
+			}
+		}
+	}
+}
+
+func TestRSDecoderTooManyErrors(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	message := []byte("Reed-Solomon over GF[2^8]")
+	numParity := 16
+	d := f.NewRSDecoder(numParity)
+	codeword := toBytes(f.RSEncode(fromBytes(message), numParity))
+	random := rand.New(rand.NewSource(1))
+	for numErrors := numParity/2 + 1; numErrors <= numParity; numErrors++ {
+		for trial := 0; trial < 20; trial++ {
+			received := append([]byte{}, codeword...)
+			for _, i := range random.Perm(len(received))[:numErrors] {
+				received[i] ^= byte(1 + random.Intn(255))
+			}
+			if _, _, err := d.Decode(received); !errors.Is(err, ErrUncorrectable) {
+				t.Errorf("%d errors with %d parity symbols: expected ErrUncorrectable, got %v.", numErrors, numParity, err)
+			}
+		}
+	}
+	if _, _, err := d.Decode(make([]byte, numParity-1)); err == nil {
+		t.Errorf("Expected error for too short codeword.")
+	}
+	if _, _, err := d.Decode(make([]byte, 256)); err == nil {
+		t.Errorf("Expected error for too long codeword.")
+	}
+}
+
+func TestRSDecoderNegativeParity(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected NewRSDecoder(-1) to panic.")
+		}
+	}()
+	f.NewRSDecoder(-1)
+}
+
+func TestRSDecoderWithErasures(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	message := []byte("Reed-Solomon over GF[2^8]")
+	numParity := 10
+	d := f.NewRSDecoder(numParity)
+	codeword := toBytes(f.RSEncode(fromBytes(message), numParity))
+	random := rand.New(rand.NewSource(1))
+	testData := []struct {
+		numErasures, numErrors int
+	}{
+		{0, 5},
+		{1, 4},
+		{2, 4},
+		{4, 3},
+		{6, 2},
+		{8, 1},
+		{10, 0},
+	}
+	for _, data := range testData {
+		for trial := 0; trial < 20; trial++ {
+			received := append([]byte{}, codeword...)
+			perm := random.Perm(len(received))
+			erasures := perm[:data.numErasures]
+			for _, i := range erasures {
+				received[i] = 0 // The erased value may or may not be correct.
+			}
+			for _, i := range perm[data.numErasures : data.numErasures+data.numErrors] {
+				received[i] ^= byte(1 + random.Intn(255))
+			}
+			decoded, err := d.DecodeWithErasures(received, erasures)
+			if err != nil {
+				t.Errorf("%d erasures and %d errors: got error %v", data.numErasures, data.numErrors, err)
+				continue
+			}
+			if !bytes.Equal(decoded, message) {
+				t.Errorf("%d erasures and %d errors: decoded %q.", data.numErasures, data.numErrors, decoded)
+			}
+		}
+	}
+	// Eight corrupted symbols exceed the error-only capability of five,
+	// but are correctable when six of their positions are known.
+	received := append([]byte{}, codeword...)
+	corrupted := []int{0, 3, 7, 12, 18, 22, 29, 34}
+	for _, i := range corrupted {
+		received[i] ^= 0x5a
+	}
+	if _, _, err := d.Decode(received); err == nil {
+		t.Errorf("Expected error when decoding without erasure information.")
+	}
+	if decoded, err := d.DecodeWithErasures(received, corrupted[:6]); err != nil {
+		t.Errorf("Decoding with erasures: got error %v", err)
+	} else if !bytes.Equal(decoded, message) {
+		t.Errorf("Decoding with erasures: decoded %q.", decoded)
+	}
+	// Too many erasures and invalid erasure positions are rejected.
+	if _, err := d.DecodeWithErasures(received, corrupted[:3]); !errors.Is(err, ErrUncorrectable) {
+		t.Errorf("Expected ErrUncorrectable, got %v.", err)
+	}
+	if _, err := d.DecodeWithErasures(codeword, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}); !errors.Is(err, ErrUncorrectable) {
+		t.Errorf("Expected ErrUncorrectable for too many erasures, got %v.", err)
+	}
+	if _, err := d.DecodeWithErasures(codeword, []int{len(codeword)}); err == nil {
+		t.Errorf("Expected error for out-of-range erasure.")
+	}
+	if _, err := d.DecodeWithErasures(codeword, []int{1, 1}); err == nil {
+		t.Errorf("Expected error for duplicate erasure.")
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gf256
+
+import (
+	"fmt"
+	"hash"
+)
+
+// rsHasher computes Reed-Solomon parity incrementally. See NewRSHasher.
+type rsHasher struct {
+	f *Field
+	// generator is RSGeneratorPoly(len(remainder)).
+	generator Polynomial
+	// remainder holds the remainder of the data written so far, multiplied
+	// by x^len(remainder), modulo generator.
+	remainder Polynomial
+}
+
+// NewRSHasher returns a hash.Hash whose sum is the numParity Reed-Solomon
+// parity symbols of the data written to it. The first byte written is the
+// highest-order coefficient of the message, and Sum appends the parity
+// symbols in the same order, so the data followed by its sum is a codeword
+// as in QR codes. The parity agrees with RSEncode on the reversed data.
+// The running remainder is updated as data is written, so memory use does
+// not depend on the amount of data. NewRSHasher panics unless f has 256
+// elements, since every byte must be a field element and vice versa, and if
+// numParity is negative.
+func (f *Field) NewRSHasher(numParity int) hash.Hash {
+	if f.m != 8 {
+		panic(fmt.Sprintf("NewRSHasher: GF(2^%d) symbols are not bytes.", f.m))
+	}
+	if numParity < 0 {
+		panic(fmt.Sprintf("NewRSHasher: negative number of parity symbols %d.", numParity))
+	}
+	return &rsHasher{
+		f:         f,
+		generator: f.RSGeneratorPoly(numParity),
+		remainder: make(Polynomial, numParity),
+	}
+}
+
+// Write feeds p into the hash, one symbol per byte. It never returns an error.
+func (h *rsHasher) Write(p []byte) (int, error) {
+	f, r, g := h.f, h.remainder, h.generator
+	n := len(r)
+	if n == 0 {
+		return len(p), nil
+	}
+	for _, b := range p {
+		// Multiplying the remainder by x and adding b×x^n overflows into
+		// the x^n term, which is replaced by x^n mod g; subtraction is
+		// addition in GF[2⁸] and g is monic.
+		feedback := f.Add(Num(b), r[n-1])
+		for i := n - 1; i > 0; i-- {
+			r[i] = f.Add(r[i-1], f.Mul(feedback, g[i]))
+		}
+		r[0] = f.Mul(feedback, g[0])
+	}
+	return len(p), nil
+}
+
+// Sum appends the parity symbols, highest-order coefficient first, to b
+// without changing the state of the hash.
+func (h *rsHasher) Sum(b []byte) []byte {
+	for i := len(h.remainder) - 1; i >= 0; i-- {
+		b = append(b, byte(h.remainder[i]))
+	}
+	return b
+}
+
+// Reset restores the hash to its initial state.
+func (h *rsHasher) Reset() {
+	for i := range h.remainder {
+		h.remainder[i] = h.f.Zero()
+	}
+}
+
+// Size returns the number of parity symbols.
+func (h *rsHasher) Size() int {
+	return len(h.remainder)
+}
+
+// BlockSize returns 1, since the hash consumes one symbol at a time.
+func (h *rsHasher) BlockSize() int {
+	return 1
+}
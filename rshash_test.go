@@ -0,0 +1,100 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gf256
+
+import "bytes"
+import "fmt"
+import "math/rand"
+import "testing"
+
+func ExampleField_NewRSHasher() {
+	f, _ := NewField(0x11d, 0x2)
+	h := f.NewRSHasher(10)
+	h.Write([]byte{32, 91, 11, 120, 209, 114, 220, 77})
+	h.Write([]byte{67, 64, 236, 17, 236, 17, 236, 17})
+	fmt.Println(h.Sum(nil))
+	// Output:
+	// [196 35 39 119 235 215 231 226 93 23]
+}
+
+func TestRSHasher(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	random := rand.New(rand.NewSource(1))
+	for _, numParity := range []int{0, 1, 2, 10, 32} {
+		h := f.NewRSHasher(numParity)
+		if h.Size() != numParity || h.BlockSize() != 1 {
+			t.Errorf("Unexpected size %d and block size %d.", h.Size(), h.BlockSize())
+		}
+		for _, length := range []int{0, 1, 17, 200, 1000} {
+			data := make([]byte, length)
+			random.Read(data)
+			h.Reset()
+			// Write in randomly sized chunks.
+			for rest := data; len(rest) > 0; {
+				n := 1 + random.Intn(len(rest))
+				if written, err := h.Write(rest[:n]); written != n || err != nil {
+					t.Errorf("Write: unexpected result %d, %v.", written, err)
+				}
+				rest = rest[n:]
+			}
+			codeword := f.RSEncode(reversed(data), numParity)
+			expected := toBytes(codeword[:numParity])
+			for i, j := 0, len(expected)-1; i < j; i, j = i+1, j-1 {
+				expected[i], expected[j] = expected[j], expected[i]
+			}
+			prefix := []byte{0xca, 0xfe}
+			sum := h.Sum(prefix)
+			if !bytes.Equal(sum[:2], prefix) || !bytes.Equal(sum[2:], expected) {
+				t.Errorf("%d parity symbols, %d bytes: expected %v, got %v.", numParity, length, expected, sum[2:])
+			}
+			// Sum must not change the state.
+			if again := h.Sum(nil); !bytes.Equal(again, expected) {
+				t.Errorf("Second Sum: expected %v, got %v.", expected, again)
+			}
+		}
+	}
+}
+
+func TestRSHasherRequiresBytes(t *testing.T) {
+	f, err := NewFieldGF2m(4, 0x13, 0x2)
+	if err != nil {
+		t.Errorf("Could not create GF(2^4): %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected NewRSHasher to panic for GF(2^4).")
+		}
+	}()
+	f.NewRSHasher(4)
+}
+
+func TestRSHasherNegativeParity(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected NewRSHasher(-1) to panic.")
+		}
+	}()
+	f.NewRSHasher(-1)
+}
@@ -0,0 +1,106 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gf256
+
+import (
+	"fmt"
+	"io"
+)
+
+// Share is one share of a secret split using Shamir's secret sharing
+// scheme: the value Y of a secret polynomial at the point X.
+type Share struct {
+	X Num
+	Y Num
+}
+
+// Split splits secret into the given number of shares such that any
+// threshold of them can reconstruct secret using Combine, while fewer
+// reveal nothing about it. Randomness is read from rand, which should be
+// a cryptographically secure source such as crypto/rand.Reader. At most
+// 255 shares, or 2^m-1 in GF(2^m), can be created. Split returns an error
+// wrapping ErrOutOfRange if secret is not an element of f.
+func (f *Field) Split(secret Num, threshold, shares int, rand io.Reader) ([]Share, error) {
+	if !f.inRange(secret) {
+		return nil, fmt.Errorf("Split: %w: secret %v", ErrOutOfRange, secret)
+	}
+	if threshold < 1 {
+		return nil, fmt.Errorf("Split: threshold %d is less than one.", threshold)
+	}
+	if shares < threshold {
+		return nil, fmt.Errorf("Split: %d shares is less than threshold %d.", shares, threshold)
+	}
+	if shares > len(f.expTable) {
+		return nil, fmt.Errorf("Split: %d shares is more than %d.", shares, len(f.expTable))
+	}
+	// The secret polynomial has the secret as constant term and
+	// random coefficients for all other terms.
+	random, err := f.randomElements(rand, threshold-1)
+	if err != nil {
+		return nil, fmt.Errorf("Split: reading randomness: %v", err)
+	}
+	p := append(Polynomial{secret}, random...)
+	result := make([]Share, shares)
+	for i := range result {
+		x := Num(i + 1)
+		result[i] = Share{X: x, Y: f.EvaluatePolynomial(p, x)}
+	}
+	return result, nil
+}
+
+// randomElements returns n uniformly random elements of the field f read
+// from rand. Each element is read as enough bytes to cover the m bits of a
+// field element.
+func (f *Field) randomElements(rand io.Reader, n int) ([]Num, error) {
+	bytesPerElement := int(f.m+7) / 8
+	random := make([]byte, n*bytesPerElement)
+	if _, err := io.ReadFull(rand, random); err != nil {
+		return nil, err
+	}
+	elements := make([]Num, n)
+	for i := range elements {
+		x := Num(0)
+		for _, b := range random[i*bytesPerElement : (i+1)*bytesPerElement] {
+			x = x<<8 | Num(b)
+		}
+		elements[i] = x & Num(len(f.logTable)-1)
+	}
+	return elements, nil
+}
+
+// Combine reconstructs the secret from shares created by Split, or returns
+// an error if shares is empty or contains duplicate x-coordinates. If fewer
+// than threshold shares are supplied, the result is unrelated to the secret.
+// Combine returns an error wrapping ErrOutOfRange if a coordinate of a share
+// is not an element of f.
+func (f *Field) Combine(shares []Share) (Num, error) {
+	if len(shares) == 0 {
+		return f.Zero(), fmt.Errorf("Combine: no shares.")
+	}
+	xs := make([]Num, len(shares))
+	ys := make([]Num, len(shares))
+	for i, share := range shares {
+		if !f.inRange(share.X) || !f.inRange(share.Y) {
+			return f.Zero(), fmt.Errorf("Combine: %w: share %d is (%v, %v)", ErrOutOfRange, i, share.X, share.Y)
+		}
+		xs[i] = share.X
+		ys[i] = share.Y
+	}
+	p, err := f.Interpolate(xs, ys)
+	if err != nil {
+		return f.Zero(), err
+	}
+	return p[0], nil
+}
@@ -0,0 +1,191 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gf256
+
+import "bytes"
+import "crypto/rand"
+import "errors"
+import "fmt"
+import mathrand "math/rand"
+import "testing"
+
+func ExampleField_Split() {
+	f, _ := NewField(0x11d, 0x2)
+	shares, _ := f.Split(Num(0x17), 3, 5, rand.Reader)
+	secret, _ := f.Combine([]Share{shares[4], shares[0], shares[2]})
+	fmt.Println(secret)
+	// Output:
+	// 10111
+}
+
+func TestSplitAndCombine(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	random := mathrand.New(mathrand.NewSource(1))
+	for threshold := 1; threshold <= 5; threshold++ {
+		for secret := uint(0); secret < 256; secret++ {
+			shares, err := f.Split(Num(secret), threshold, 5, random)
+			if err != nil {
+				t.Errorf("Split(%v, %d, 5): got error %v", Num(secret), threshold, err)
+				continue
+			}
+			// Every subset of exactly threshold shares reconstructs the secret.
+			for mask := 0; mask < 1<<5; mask++ {
+				var subset []Share
+				for i := range shares {
+					if mask&(1<<uint(i)) != 0 {
+						subset = append(subset, shares[i])
+					}
+				}
+				if len(subset) != threshold {
+					continue
+				}
+				actual, err := f.Combine(subset)
+				if err != nil {
+					t.Errorf("Combine(%v): got error %v", subset, err)
+				}
+				if actual != Num(secret) {
+					t.Errorf("Combine(%v): expected %v, got %v.", subset, Num(secret), actual)
+				}
+			}
+		}
+	}
+}
+
+func TestCombineWithTooFewShares(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	// With threshold-1 shares, every candidate secret is consistent
+	// with the shares: adding the point (0, candidate) yields a
+	// polynomial of degree below threshold through all the shares, so
+	// the shares carry no information about the secret.
+	const threshold = 3
+	random := mathrand.New(mathrand.NewSource(1))
+	shares, err := f.Split(Num(0x17), threshold, 5, random)
+	if err != nil {
+		t.Errorf("Split: got error %v", err)
+		return
+	}
+	incomplete := shares[:threshold-1]
+	for candidate := uint(0); candidate < 256; candidate++ {
+		xs := []Num{0x00}
+		ys := []Num{Num(candidate)}
+		for _, share := range incomplete {
+			xs = append(xs, share.X)
+			ys = append(ys, share.Y)
+		}
+		p, err := f.Interpolate(xs, ys)
+		if err != nil {
+			t.Errorf("Interpolate: got error %v", err)
+			continue
+		}
+		if f.Degree(p) >= threshold {
+			t.Errorf("Candidate %v: degree %d is not below threshold %d.", Num(candidate), f.Degree(p), threshold)
+		}
+		if y := f.EvaluatePolynomial(p, 0x00); y != Num(candidate) {
+			t.Errorf("Candidate %v: polynomial %v has value %v at 0.", Num(candidate), p, y)
+		}
+		for _, share := range incomplete {
+			if y := f.EvaluatePolynomial(p, share.X); y != share.Y {
+				t.Errorf("Candidate %v: polynomial %v has value %v at %v, share has %v.", Num(candidate), p, y, share.X, share.Y)
+			}
+		}
+	}
+	if secret, _ := f.Combine(incomplete); secret == Num(0x17) {
+		t.Errorf("Combine with too few shares unexpectedly recovered the secret.")
+	}
+}
+
+func TestSplitBytewise(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	secret := []byte("a multi-byte secret")
+	random := mathrand.New(mathrand.NewSource(1))
+	byteShares := make([][]Share, len(secret))
+	for i, b := range secret {
+		byteShares[i], err = f.Split(Num(b), 2, 3, random)
+		if err != nil {
+			t.Errorf("Split: got error %v", err)
+			return
+		}
+	}
+	recovered := make([]byte, len(secret))
+	for i, shares := range byteShares {
+		n, err := f.Combine([]Share{shares[2], shares[1]})
+		if err != nil {
+			t.Errorf("Combine: got error %v", err)
+		}
+		recovered[i] = byte(n)
+	}
+	if !bytes.Equal(secret, recovered) {
+		t.Errorf("Expected %q, got %q.", secret, recovered)
+	}
+}
+
+func TestSplitAndCombineErrors(t *testing.T) {
+	f, err := NewField(0x11d, 0x02)
+	if err != nil {
+		t.Errorf("Could not create GF[2⁸]: %v.", err)
+		return // Avoid crashing due to dereferencing nil below.
+	}
+	if _, err := f.Split(0x17, 0, 5, rand.Reader); err == nil {
+		t.Errorf("Expected error for zero threshold.")
+	}
+	if _, err := f.Split(0x17, 3, 2, rand.Reader); err == nil {
+		t.Errorf("Expected error for fewer shares than threshold.")
+	}
+	if _, err := f.Split(0x17, 3, 256, rand.Reader); err == nil {
+		t.Errorf("Expected error for too many shares.")
+	}
+	if _, err := f.Split(0x17, 3, 5, bytes.NewReader(nil)); err == nil {
+		t.Errorf("Expected error for exhausted randomness.")
+	}
+	if _, err := f.Combine(nil); err == nil {
+		t.Errorf("Expected error for no shares.")
+	}
+	if _, err := f.Combine([]Share{{0x01, 0x02}, {0x01, 0x03}}); err == nil {
+		t.Errorf("Expected error for duplicate shares.")
+	}
+	for _, secret := range []Num{256, 1000} {
+		if _, err := f.Split(secret, 2, 3, rand.Reader); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("Split(%v): expected ErrOutOfRange, got %v.", secret, err)
+		}
+	}
+	for _, shares := range [][]Share{{{0x01, 0x02}, {256, 0x03}}, {{0x01, 0x02}, {0x02, 1000}}} {
+		if _, err := f.Combine(shares); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("Combine(%v): expected ErrOutOfRange, got %v.", shares, err)
+		}
+	}
+	g, err := NewFieldGF2m(4, 0x13, 0x2)
+	if err != nil {
+		t.Errorf("Could not create GF(2^4): %v.", err)
+		return
+	}
+	if _, err := g.Split(0x10, 2, 3, rand.Reader); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("GF(2^4): Split(0x10): expected ErrOutOfRange, got %v.", err)
+	}
+	if _, err := g.Combine([]Share{{0x01, 0x10}, {0x02, 0x03}}); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("GF(2^4): Combine with Y == 0x10: expected ErrOutOfRange, got %v.", err)
+	}
+}